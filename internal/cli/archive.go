@@ -0,0 +1,375 @@
+package cli
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"agent-skills/internal/installer"
+)
+
+type githubRelease struct {
+	TagName    string `json:"tag_name"`
+	TarballURL string `json:"tarball_url"`
+}
+
+func fetchLatestRelease(ctx context.Context, clone cloneOptions, repo string) (string, func(), error) {
+	owner, name, ok := githubRepoSlug(repo)
+	if !ok {
+		return "", nil, fmt.Errorf("--release needs a GitHub repo (owner/name or github.com URL), got %q", repo)
+	}
+	release, err := githubLatestRelease(ctx, clone, owner, name)
+	if err != nil {
+		return "", nil, err
+	}
+	fmt.Printf("Using release %s of %s/%s\n", release.TagName, owner, name)
+	return downloadArchive(ctx, clone, release.TarballURL)
+}
+
+func githubLatestRelease(ctx context.Context, clone cloneOptions, owner, name string) (githubRelease, error) {
+	url := fmt.Sprintf("https://api.github.com/repos/%s/%s/releases/latest", owner, name)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return githubRelease{}, err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	clone.authorize(req)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return githubRelease{}, fmt.Errorf("query latest release of %s/%s: %w", owner, name, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return githubRelease{}, fmt.Errorf("%s/%s has no published releases", owner, name)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return githubRelease{}, fmt.Errorf("query latest release of %s/%s: %s", owner, name, resp.Status)
+	}
+	var release githubRelease
+	if err := json.NewDecoder(resp.Body).Decode(&release); err != nil {
+		return githubRelease{}, fmt.Errorf("decode latest release of %s/%s: %w", owner, name, err)
+	}
+	if release.TarballURL == "" {
+		return githubRelease{}, fmt.Errorf("latest release of %s/%s has no source tarball", owner, name)
+	}
+	return release, nil
+}
+
+func githubRepoSize(ctx context.Context, clone cloneOptions, owner, name string) (int64, error) {
+	url := fmt.Sprintf("https://api.github.com/repos/%s/%s", owner, name)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	clone.authorize(req)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("query %s/%s: %s", owner, name, resp.Status)
+	}
+	var repo struct {
+		Size int64 `json:"size"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&repo); err != nil {
+		return 0, err
+	}
+	return repo.Size * 1024, nil
+}
+
+func githubRepoSlug(repo string) (string, string, bool) {
+	value := strings.TrimSpace(repo)
+	for _, prefix := range []string{"https://github.com/", "http://github.com/", "git@github.com:", "github.com/"} {
+		if strings.HasPrefix(value, prefix) {
+			value = strings.TrimPrefix(value, prefix)
+			break
+		}
+	}
+	value = strings.TrimSuffix(strings.TrimSuffix(value, "/"), ".git")
+	parts := strings.Split(value, "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" || strings.Contains(value, ":") {
+		return "", "", false
+	}
+	return parts[0], parts[1], true
+}
+
+func downloadArchive(ctx context.Context, clone cloneOptions, url string) (string, func(), error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", nil, err
+	}
+	clone.authorize(req)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", nil, fmt.Errorf("download %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", nil, fmt.Errorf("download %s: %s", url, resp.Status)
+	}
+	tempDir, err := os.MkdirTemp("", "askill-repo-*")
+	if err != nil {
+		return "", nil, err
+	}
+	cleanup := registerCleanup(func() { _ = os.RemoveAll(tempDir) })
+	if err := extractTarGz(resp.Body, tempDir); err != nil {
+		cleanup()
+		return "", nil, fmt.Errorf("extract %s: %w", url, err)
+	}
+	return archiveRoot(tempDir), cleanup, nil
+}
+
+func isSkillsArchive(value string) bool {
+	name := value
+	if remote, ok := archiveURL(value); ok {
+		name = remote.Path
+	}
+	name = strings.ToLower(name)
+	return strings.HasSuffix(name, ".tar.gz") || strings.HasSuffix(name, ".tgz") || strings.HasSuffix(name, ".zip")
+}
+
+func archiveURL(value string) (*url.URL, bool) {
+	parsed, err := url.Parse(value)
+	if err != nil || parsed.Host == "" || parsed.Scheme != "http" && parsed.Scheme != "https" {
+		return nil, false
+	}
+	return parsed, true
+}
+
+func openSkillsArchive(ctx context.Context, clone cloneOptions, value string) (string, func(), error) {
+	path := value
+	if _, ok := archiveURL(value); ok {
+		downloaded, err := downloadArchiveFile(ctx, clone, value)
+		if err != nil {
+			return "", nil, err
+		}
+		defer os.Remove(downloaded)
+		path = downloaded
+	}
+	tempDir, err := os.MkdirTemp("", "askill-repo-*")
+	if err != nil {
+		return "", nil, err
+	}
+	cleanup := registerCleanup(func() { _ = os.RemoveAll(tempDir) })
+	if strings.HasSuffix(strings.ToLower(path), ".zip") {
+		err = extractZip(path, tempDir)
+	} else {
+		err = extractTarGzFile(path, tempDir)
+	}
+	if err != nil {
+		cleanup()
+		return "", nil, fmt.Errorf("extract %s: %w", value, err)
+	}
+	debugf("extracted %s to %s", value, tempDir)
+	return archiveRoot(tempDir), cleanup, nil
+}
+
+func downloadArchiveFile(ctx context.Context, clone cloneOptions, rawURL string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return "", err
+	}
+	clone.authorize(req)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("download %s: %w", rawURL, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("download %s: %s", rawURL, resp.Status)
+	}
+	remote, _ := archiveURL(rawURL)
+	suffix := ".tar.gz"
+	if strings.HasSuffix(strings.ToLower(remote.Path), ".zip") {
+		suffix = ".zip"
+	}
+	out, err := os.CreateTemp("", "askill-archive-*"+suffix)
+	if err != nil {
+		return "", err
+	}
+	debugf("download %s to %s", rawURL, out.Name())
+	if _, err := io.Copy(out, resp.Body); err != nil {
+		out.Close()
+		_ = os.Remove(out.Name())
+		return "", fmt.Errorf("download %s: %w", rawURL, err)
+	}
+	if err := out.Close(); err != nil {
+		_ = os.Remove(out.Name())
+		return "", err
+	}
+	return out.Name(), nil
+}
+
+func extractTarGzFile(path, dest string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	return extractTarGz(file, dest)
+}
+
+func extractZip(path, dest string) error {
+	reader, err := zip.OpenReader(path)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+	for _, file := range reader.File {
+		target, err := archiveEntryPath(dest, file.Name)
+		if err != nil {
+			return err
+		}
+		mode := file.Mode()
+		if mode.Perm() == 0 {
+			mode |= 0o644
+		}
+		switch {
+		case mode.IsDir():
+			if err := os.MkdirAll(target, 0o755); err != nil {
+				return err
+			}
+		case mode&os.ModeSymlink != 0:
+			link, err := readZipEntry(file)
+			if err != nil {
+				return err
+			}
+			if err := writeArchiveSymlink(dest, target, file.Name, string(link)); err != nil {
+				return err
+			}
+		case mode.IsRegular():
+			rc, err := file.Open()
+			if err != nil {
+				return err
+			}
+			err = writeArchiveFile(target, rc, mode)
+			rc.Close()
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func readZipEntry(file *zip.File) ([]byte, error) {
+	rc, err := file.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	return io.ReadAll(rc)
+}
+
+func extractTarGz(r io.Reader, dest string) error {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return err
+	}
+	defer gz.Close()
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		target, err := archiveEntryPath(dest, header.Name)
+		if err != nil {
+			return err
+		}
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, 0o755); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err := writeArchiveFile(target, tr, header.FileInfo().Mode()); err != nil {
+				return err
+			}
+		case tar.TypeSymlink:
+			if err := writeArchiveSymlink(dest, target, header.Name, header.Linkname); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func archiveEntryPath(dest, name string) (string, error) {
+	target := filepath.Join(dest, name)
+	if !withinDir(dest, target) {
+		return "", fmt.Errorf("archive entry %q escapes destination", name)
+	}
+	rel, _ := filepath.Rel(filepath.Clean(dest), target)
+	current := filepath.Clean(dest)
+	for _, part := range strings.Split(rel, string(os.PathSeparator)) {
+		if part == "." {
+			continue
+		}
+		current = filepath.Join(current, part)
+		info, err := os.Lstat(current)
+		if err != nil {
+			break
+		}
+		if info.Mode()&os.ModeSymlink != 0 {
+			return "", fmt.Errorf("archive entry %q writes through symlink %s", name, current)
+		}
+	}
+	return target, nil
+}
+
+func withinDir(dir, path string) bool {
+	dir = filepath.Clean(dir)
+	return path == dir || strings.HasPrefix(path, dir+string(os.PathSeparator))
+}
+
+func writeArchiveSymlink(dest, target, name, link string) error {
+	if link == "" || filepath.IsAbs(link) || !withinDir(dest, filepath.Join(filepath.Dir(target), link)) {
+		return fmt.Errorf("archive entry %q links outside the destination (%s)", name, link)
+	}
+	if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+		return err
+	}
+	return os.Symlink(link, target)
+}
+
+func writeArchiveFile(target string, r io.Reader, mode os.FileMode) error {
+	if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+		return err
+	}
+	out, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, mode.Perm())
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, r); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+func archiveRoot(dir string) string {
+	if installer.ExistsDir(filepath.Join(dir, "skills")) {
+		return dir
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil || len(entries) != 1 || !entries[0].IsDir() {
+		return dir
+	}
+	return filepath.Join(dir, entries[0].Name())
+}
@@ -0,0 +1,150 @@
+package cli
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+type archiveEntry struct {
+	name string
+	link string
+	body string
+	dir  bool
+}
+
+func tarGz(t *testing.T, entries []archiveEntry) *bytes.Buffer {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for _, entry := range entries {
+		header := &tar.Header{Name: entry.name, Mode: 0o644, Typeflag: tar.TypeReg, Size: int64(len(entry.body))}
+		switch {
+		case entry.dir:
+			header.Typeflag, header.Mode, header.Size = tar.TypeDir, 0o755, 0
+		case entry.link != "":
+			header.Typeflag, header.Linkname, header.Size = tar.TypeSymlink, entry.link, 0
+		}
+		if err := tw.WriteHeader(header); err != nil {
+			t.Fatal(err)
+		}
+		if header.Typeflag == tar.TypeReg {
+			if _, err := tw.Write([]byte(entry.body)); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return &buf
+}
+
+func TestExtractTarGzRejectsEscapes(t *testing.T) {
+	tests := []struct {
+		name    string
+		entries []archiveEntry
+		want    string
+	}{
+		{"dotdot name", []archiveEntry{{name: "../x", body: "x"}}, "escapes destination"},
+		{"absolute link", []archiveEntry{{name: "repo/link", link: "/etc"}}, "links outside"},
+		{"relative link out", []archiveEntry{{name: "repo/link", link: "../../outside"}}, "links outside"},
+		{"write through link", []archiveEntry{{name: "repo/link", link: "."}, {name: "repo/link/x", body: "x"}}, "through symlink"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parent := t.TempDir()
+			dest := filepath.Join(parent, "dest")
+			err := extractTarGz(tarGz(t, tt.entries), dest)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("extractTarGz error = %v, want %q", err, tt.want)
+			}
+			if _, err := os.Lstat(filepath.Join(parent, "x")); err == nil {
+				t.Fatal("entry was written outside the destination")
+			}
+		})
+	}
+}
+
+func TestExtractTarGzKeepsContainedLinks(t *testing.T) {
+	dest := t.TempDir()
+	entries := []archiveEntry{
+		{name: "repo/skills/", dir: true},
+		{name: "repo/skills/a/SKILL.md", body: "a"},
+		{name: "repo/skills/b", link: "a"},
+	}
+	if err := extractTarGz(tarGz(t, entries), dest); err != nil {
+		t.Fatal(err)
+	}
+	link, err := os.Readlink(filepath.Join(dest, "repo", "skills", "b"))
+	if err != nil || link != "a" {
+		t.Fatalf("link = %q, %v; want a", link, err)
+	}
+}
+
+func writeZip(t *testing.T, entries []archiveEntry) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "repo.zip")
+	file, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	zw := zip.NewWriter(file)
+	for _, entry := range entries {
+		header := &zip.FileHeader{Name: entry.name, Method: zip.Deflate}
+		body := entry.body
+		switch {
+		case entry.dir:
+			header.SetMode(os.ModeDir | 0o755)
+		case entry.link != "":
+			header.SetMode(os.ModeSymlink | 0o777)
+			body = entry.link
+		default:
+			header.SetMode(0o644)
+		}
+		w, err := zw.CreateHeader(header)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write([]byte(body)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := file.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestExtractZipRejectsEscapes(t *testing.T) {
+	tests := []struct {
+		name    string
+		entries []archiveEntry
+		want    string
+	}{
+		{"absolute link", []archiveEntry{{name: "repo/link", link: "/etc"}}, "links outside"},
+		{"relative link out", []archiveEntry{{name: "repo/link", link: "../../outside"}}, "links outside"},
+		{"write through link", []archiveEntry{{name: "repo/link", link: "."}, {name: "repo/link/x", body: "x"}}, "through symlink"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dest := filepath.Join(t.TempDir(), "dest")
+			err := extractZip(writeZip(t, tt.entries), dest)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("extractZip error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
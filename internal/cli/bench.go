@@ -0,0 +1,95 @@
+package cli
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"text/tabwriter"
+	"time"
+
+	"agent-skills/internal/installer"
+)
+
+var benchModes = []installer.Mode{installer.ModeCopy, installer.ModeSymlink, installer.ModeHardlink}
+
+func runBenchCommand(args []string, cmdName string) error {
+	fs := flag.NewFlagSet(cmdName+" bench", flag.ContinueOnError)
+	fs.SetOutput(os.Stderr)
+	var files int
+	var size int
+	fs.IntVar(&files, "files", 200, "number of files in the synthetic skill")
+	fs.IntVar(&size, "size", 64*1024, "size of each file in bytes")
+	fs.Usage = func() {
+		out := fs.Output()
+		fmt.Fprintf(out, "Usage: %s bench [--files N] [--size BYTES]\n\n", cmdName)
+		fmt.Fprintln(out, "Measure install throughput on a synthetic skill.")
+		fmt.Fprintln(out)
+		fmt.Fprintln(out, "Options:")
+		tw := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(tw, "  --files\tNumber of files in the synthetic skill (default 200)")
+		fmt.Fprintln(tw, "  --size\tSize of each file in bytes (default 65536)")
+		fmt.Fprintln(tw, "  -h, --help\tShow help")
+		_ = tw.Flush()
+	}
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+	if files < 1 || size < 0 {
+		return errors.New("--files must be at least 1 and --size must not be negative")
+	}
+
+	tempDir, err := os.MkdirTemp("", "askill-bench-*")
+	if err != nil {
+		return err
+	}
+	defer registerCleanup(func() { _ = os.RemoveAll(tempDir) })()
+
+	src := filepath.Join(tempDir, "src", "bench-skill")
+	if err := writeBenchSkill(src, files, size); err != nil {
+		return fmt.Errorf("generate synthetic skill: %w", err)
+	}
+	totalMB := float64(files) * float64(size) / (1024 * 1024)
+	fmt.Printf("Synthetic skill: %d files, %.1f MB\n\n", files, totalMB)
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "MODE\tDURATION\tFILES/S\tMB/S")
+	for _, mode := range benchModes {
+		dest := filepath.Join(tempDir, "dest-"+string(mode), "bench-skill")
+		start := time.Now()
+		if err := installer.InstallSkill(src, dest, mode); err != nil {
+			return fmt.Errorf("bench %s: %w", mode, err)
+		}
+		elapsed := time.Since(start)
+		seconds := max(elapsed.Seconds(), 1e-9)
+		fmt.Fprintf(tw, "%s\t%s\t%.0f\t%.1f\n", mode, elapsed.Round(time.Microsecond), float64(files)/seconds, totalMB/seconds)
+	}
+	return tw.Flush()
+}
+
+func writeBenchSkill(dir string, files, size int) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(dir, "SKILL.md"), []byte("---\nname: bench-skill\ndescription: synthetic benchmark skill\n---\n"), 0o644); err != nil {
+		return err
+	}
+	data := make([]byte, size)
+	for i := range data {
+		data[i] = byte(i % 251)
+	}
+	for i := 1; i < files; i++ {
+		sub := filepath.Join(dir, "data", fmt.Sprintf("%03d", i/50))
+		if err := os.MkdirAll(sub, 0o755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(filepath.Join(sub, fmt.Sprintf("file-%05d.bin", i)), data, 0o644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
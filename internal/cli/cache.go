@@ -0,0 +1,220 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	defaultRefreshCooldown = 15 * time.Minute
+	defaultCacheTTL        = 24 * time.Hour
+)
+
+func repoCacheRoot() (string, error) {
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(cacheDir, "askill", "repos"), nil
+}
+
+func repoCacheDir(repoURL string) (string, error) {
+	root, err := repoCacheRoot()
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256([]byte(repoURL))
+	return filepath.Join(root, hex.EncodeToString(sum[:8])), nil
+}
+
+func usableCacheDir(clone cloneOptions, key string) (string, bool) {
+	if clone.noCache {
+		debugf("--no-cache: cloning to a temp dir")
+		return "", false
+	}
+	dir, err := repoCacheDir(key)
+	if err == nil {
+		err = os.MkdirAll(filepath.Dir(dir), 0o755)
+	}
+	if err != nil {
+		debugf("clone cache unavailable (%v); cloning to a temp dir", err)
+		return "", false
+	}
+	return dir, true
+}
+
+func cachedClone(ctx context.Context, clone cloneOptions, repoURL, ref, dir string) error {
+	if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+		if cacheRemoteMatches(ctx, clone, dir, repoURL) {
+			if !clone.shouldRefresh(dir) {
+				debugf("reusing cached clone %s", dir)
+				return nil
+			}
+			err := refreshCache(ctx, clone, dir, ref)
+			if err == nil {
+				return nil
+			}
+			if clone.refresh {
+				return err
+			}
+			fmt.Fprintf(os.Stderr, "warning: could not update stale cached clone of %s; using it as is: %v\n", repoURL, clone.redactError(err))
+			return nil
+		}
+		debugf("cached clone %s has a different origin; cloning %s again", dir, repoURL)
+		if err := os.RemoveAll(dir); err != nil {
+			return err
+		}
+	}
+	if err := os.MkdirAll(filepath.Dir(dir), 0o755); err != nil {
+		return err
+	}
+	return runGit(ctx, clone, cloneArgs(repoURL, ref, dir)...)
+}
+
+func cloneArgs(repoURL, ref, dir string) []string {
+	if ref == "" {
+		return []string{"clone", "--depth", "1", repoURL, dir}
+	}
+	return []string{"clone", "--depth", "1", "--branch", ref, "-c", "advice.detachedHead=false", repoURL, dir}
+}
+
+func splitRepoRef(repo string) (string, string) {
+	if base, ref, ok := strings.Cut(repo, "#"); ok {
+		return base, ref
+	}
+	slash := strings.LastIndex(repo, "/")
+	if at := strings.LastIndex(repo, "@"); at > slash && slash >= 0 {
+		return repo[:at], repo[at+1:]
+	}
+	return repo, ""
+}
+
+func isCommitRef(ref string) bool {
+	if len(ref) < 7 || len(ref) > 40 {
+		return false
+	}
+	for _, r := range ref {
+		if !strings.ContainsRune("0123456789abcdef", r) {
+			return false
+		}
+	}
+	return true
+}
+
+func cacheRemoteMatches(ctx context.Context, clone cloneOptions, dir, repoURL string) bool {
+	cmd := exec.CommandContext(ctx, "git", "-C", dir, "remote", "get-url", "origin")
+	cmd.Env = clone.env()
+	out, err := cmd.Output()
+	return err == nil && sameRemote(strings.TrimSpace(string(out)), repoURL)
+}
+
+func sameRemote(a, b string) bool {
+	trim := func(url string) string {
+		return strings.TrimSuffix(strings.TrimRight(url, "/"), ".git")
+	}
+	return trim(a) == trim(b)
+}
+
+func refreshCache(ctx context.Context, clone cloneOptions, dir, ref string) error {
+	args := []string{"-C", dir, "fetch", "--depth", "1", "origin"}
+	if ref != "" {
+		args = append(args, ref)
+	}
+	if err := runGit(ctx, clone, args...); err != nil {
+		return err
+	}
+	return runGit(ctx, clone, "-C", dir, "reset", "--hard", "FETCH_HEAD")
+}
+
+func (o cloneOptions) shouldRefresh(dir string) bool {
+	if o.refresh {
+		return true
+	}
+	fetched, ok := lastFetch(dir)
+	if o.refreshOnStart {
+		return !ok || time.Since(fetched) >= o.refreshCooldown
+	}
+	return o.cacheTTL > 0 && (!ok || time.Since(fetched) >= o.cacheTTL)
+}
+
+func lastFetch(dir string) (time.Time, bool) {
+	for _, name := range []string{"FETCH_HEAD", "HEAD"} {
+		if info, err := os.Stat(filepath.Join(dir, ".git", name)); err == nil {
+			return info.ModTime(), true
+		}
+	}
+	return time.Time{}, false
+}
+
+func runGit(ctx context.Context, clone cloneOptions, args ...string) error {
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Env = clone.env()
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = io.MultiWriter(clone.stderr(), &stderr)
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		op := args[0]
+		if op == "-C" && len(args) > 2 {
+			op = args[2]
+		}
+		return gitError(clone, "git "+op, stderr.String(), err)
+	}
+	return nil
+}
+
+var (
+	gitAuthFailures = []string{
+		"authentication failed",
+		"could not read username",
+		"could not read password",
+		"terminal prompts disabled",
+		"permission denied (publickey",
+		"repository not found",
+		"the requested url returned error: 401",
+		"the requested url returned error: 403",
+		"invalid username or password",
+		"host key verification failed",
+	}
+	gitNetworkFailures = []string{
+		"could not resolve host",
+		"could not resolve hostname",
+		"failed to connect",
+		"connection timed out",
+		"connection refused",
+		"network is unreachable",
+		"operation timed out",
+		"connection reset",
+	}
+)
+
+func gitError(clone cloneOptions, op, stderr string, err error) error {
+	output := strings.ToLower(stderr)
+	switch {
+	case containsAny(output, gitAuthFailures):
+		return fmt.Errorf("%s: authentication failed (%w); %s", op, err, clone.authHint())
+	case containsAny(output, gitNetworkFailures):
+		return fmt.Errorf("%s: network error, could not reach the remote (%w); check your connection or proxy", op, err)
+	}
+	return fmt.Errorf("%s: %w", op, err)
+}
+
+func containsAny(text string, needles []string) bool {
+	for _, needle := range needles {
+		if strings.Contains(text, needle) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,97 @@
+package cli
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func gitCmd(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+	cmd := exec.Command("git", append([]string{"-c", "user.name=test", "-c", "user.email=test@example.com", "-c", "init.defaultBranch=main"}, args...)...)
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %s: %v\n%s", strings.Join(args, " "), err, out)
+	}
+	return strings.TrimSpace(string(out))
+}
+
+func testRemote(t *testing.T, skill string) string {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	dir := t.TempDir()
+	gitCmd(t, dir, "init", "-q")
+	path := filepath.Join(dir, "skills", skill, "SKILL.md")
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte("---\nname: "+skill+"\n---\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	gitCmd(t, dir, "add", "-A")
+	gitCmd(t, dir, "commit", "-q", "-m", "init")
+	return "file://" + dir
+}
+
+func TestCachedCloneRecloneOnRemoteMismatch(t *testing.T) {
+	wanted := testRemote(t, "wanted")
+	poisoned := testRemote(t, "poisoned")
+	dir := filepath.Join(t.TempDir(), "cache")
+	gitCmd(t, filepath.Dir(dir), "clone", "-q", poisoned, dir)
+
+	if err := cachedClone(context.Background(), cloneOptions{}, wanted, "", dir); err != nil {
+		t.Fatal(err)
+	}
+	if got := gitCmd(t, dir, "remote", "get-url", "origin"); got != wanted {
+		t.Fatalf("origin = %s, want %s", got, wanted)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "skills", "wanted", "SKILL.md")); err != nil {
+		t.Fatalf("cache does not hold the requested repo: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "skills", "poisoned")); err == nil {
+		t.Fatal("cache still holds the mismatched repo")
+	}
+}
+
+func TestCachedCloneReusesMatchingRemote(t *testing.T) {
+	remote := testRemote(t, "kept")
+	dir := filepath.Join(t.TempDir(), "cache")
+	gitCmd(t, filepath.Dir(dir), "clone", "-q", remote, dir)
+	gitCmd(t, dir, "remote", "set-url", "origin", remote+"/")
+	marker := filepath.Join(dir, "marker")
+	if err := os.WriteFile(marker, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := cachedClone(context.Background(), cloneOptions{}, remote, "", dir); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(marker); err != nil {
+		t.Fatal("matching cached clone was replaced instead of reused")
+	}
+}
+
+func TestCachedCloneKeepsCacheWhenRefreshFails(t *testing.T) {
+	remote := testRemote(t, "kept")
+	dir := filepath.Join(t.TempDir(), "cache")
+	gitCmd(t, filepath.Dir(dir), "clone", "-q", remote, dir)
+	if err := os.RemoveAll(strings.TrimPrefix(remote, "file://")); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := cachedClone(context.Background(), cloneOptions{refreshOnStart: true}, remote, "", dir); err != nil {
+		t.Fatalf("automatic refresh failure should fall back to the cache: %v", err)
+	}
+	if err := cachedClone(context.Background(), cloneOptions{refresh: true}, remote, "", dir); err == nil {
+		t.Fatal("explicit --refresh against a missing remote should fail")
+	}
+	if _, err := os.Stat(filepath.Join(dir, "skills", "kept", "SKILL.md")); err != nil {
+		t.Fatalf("failed refresh removed the cached clone: %v", err)
+	}
+}
@@ -0,0 +1,262 @@
+package cli
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"text/tabwriter"
+)
+
+var subcommands = []string{"config", "migrate", "uninstall", "installed", "list", "validate", "bench", "doctor", "which", "info", "search", "status", "update", "prune", "completion"}
+
+var nonIdentifier = regexp.MustCompile(`[^A-Za-z0-9_]`)
+
+type completionFlag struct {
+	name       string
+	usage      string
+	takesValue bool
+}
+
+func runCompletionCommand(args []string, cmdName string, installFlags *flag.FlagSet) error {
+	fs := flag.NewFlagSet(cmdName+" completion", flag.ContinueOnError)
+	fs.SetOutput(os.Stderr)
+	var listSkillNames bool
+	var repoRoot string
+	fs.BoolVar(&listSkillNames, "list-skills", false, "print skill names from the resolved skills repo, one per line")
+	fs.StringVar(&repoRoot, "repo", "", "skills repo to read skill names from (defaults to config)")
+	fs.StringVar(&repoRoot, "r", "", "alias for --repo")
+	fs.Usage = func() {
+		out := fs.Output()
+		fmt.Fprintf(out, "Usage: %s completion bash|zsh|fish\n\n", cmdName)
+		fmt.Fprintln(out, "Print a shell completion script for subcommands, flags, and skill names.")
+		fmt.Fprintln(out)
+		fmt.Fprintln(out, "Install:")
+		tw := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+		fmt.Fprintf(tw, "  bash\techo 'source <(%s completion bash)' >> ~/.bashrc\n", cmdName)
+		fmt.Fprintf(tw, "  zsh\techo 'source <(%s completion zsh)' >> ~/.zshrc\n", cmdName)
+		fmt.Fprintf(tw, "  fish\t%s completion fish > ~/.config/fish/completions/%s.fish\n", cmdName, cmdName)
+		_ = tw.Flush()
+		fmt.Fprintln(out)
+		fmt.Fprintln(out, "Options:")
+		tw = tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(tw, "  --list-skills\tPrint skill names from the resolved skills repo, one per line")
+		fmt.Fprintln(tw, "  -r, --repo\tSkills repo to read skill names from (defaults to config)")
+		fmt.Fprintln(tw, "  -h, --help\tShow help")
+		_ = tw.Flush()
+	}
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+
+	if listSkillNames {
+		cfg, err := loadConfig()
+		if err != nil {
+			return err
+		}
+		skills, cleanup, err := listSkills(repoRoot, cfg)
+		if err != nil {
+			return err
+		}
+		if cleanup != nil {
+			defer cleanup()
+		}
+		names := make([]string, 0, len(skills))
+		for _, skill := range skills {
+			names = append(names, skill.Name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			fmt.Println(name)
+		}
+		return nil
+	}
+
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return errors.New("completion takes exactly one shell: bash, zsh, or fish")
+	}
+	flags := completionFlags(installFlags)
+	switch fs.Arg(0) {
+	case "bash":
+		fmt.Print(bashCompletion(cmdName, flags))
+	case "zsh":
+		fmt.Print(zshCompletion(cmdName, flags))
+	case "fish":
+		fmt.Print(fishCompletion(cmdName, flags))
+	default:
+		return fmt.Errorf("unsupported shell %q: want bash, zsh, or fish", fs.Arg(0))
+	}
+	return nil
+}
+
+func completionFlags(fs *flag.FlagSet) []completionFlag {
+	var flags []completionFlag
+	fs.VisitAll(func(f *flag.Flag) {
+		boolFlag, ok := f.Value.(interface{ IsBoolFlag() bool })
+		flags = append(flags, completionFlag{
+			name:       f.Name,
+			usage:      f.Usage,
+			takesValue: !ok || !boolFlag.IsBoolFlag(),
+		})
+	})
+	return flags
+}
+
+func dashedFlag(name string) string {
+	if len(name) == 1 {
+		return "-" + name
+	}
+	return "--" + name
+}
+
+func flagWords(flags []completionFlag, valueOnly bool) string {
+	words := make([]string, 0, len(flags))
+	for _, f := range flags {
+		if valueOnly && (!f.takesValue || f.name == "skills") {
+			continue
+		}
+		words = append(words, dashedFlag(f.name))
+	}
+	return strings.Join(words, " ")
+}
+
+func completionReplacer(cmdName string, flags []completionFlag) *strings.Replacer {
+	return strings.NewReplacer(
+		"@FUNC@", "_"+nonIdentifier.ReplaceAllString(cmdName, "_"),
+		"@CMD@", cmdName,
+		"@SUBCOMMANDS@", strings.Join(subcommands, " "),
+		"@FLAGS@", flagWords(flags, false),
+		"@VALUE_FLAGS@", strings.ReplaceAll(flagWords(flags, true), " ", "|"),
+	)
+}
+
+func bashCompletion(cmdName string, flags []completionFlag) string {
+	return completionReplacer(cmdName, flags).Replace(`@FUNC@() {
+    local cur prev skills
+    cur="${COMP_WORDS[COMP_CWORD]}"
+    prev="${COMP_WORDS[COMP_CWORD-1]}"
+    case "$prev" in
+    --skills)
+        COMPREPLY=($(compgen -W "$(@CMD@ completion --list-skills 2>/dev/null)" -- "$cur"))
+        return
+        ;;
+    @VALUE_FLAGS@)
+        COMPREPLY=($(compgen -f -- "$cur"))
+        return
+        ;;
+    esac
+    if [[ $COMP_CWORD -gt 1 && " @SUBCOMMANDS@ " == *" ${COMP_WORDS[1]} "* ]]; then
+        case "${COMP_WORDS[1]}" in
+        which|info)
+            [[ $cur != -* ]] && COMPREPLY=($(compgen -W "$(@CMD@ completion --list-skills 2>/dev/null)" -- "$cur"))
+            ;;
+        completion)
+            COMPREPLY=($(compgen -W "bash zsh fish" -- "$cur"))
+            ;;
+        *)
+            COMPREPLY=($(compgen -f -- "$cur"))
+            ;;
+        esac
+        return
+    fi
+    if [[ $cur == -* ]]; then
+        COMPREPLY=($(compgen -W "@FLAGS@" -- "$cur"))
+        return
+    fi
+    skills="$(@CMD@ completion --list-skills 2>/dev/null)"
+    if [[ $COMP_CWORD -eq 1 ]]; then
+        COMPREPLY=($(compgen -W "@SUBCOMMANDS@ $skills" -- "$cur"))
+    else
+        COMPREPLY=($(compgen -W "$skills" -- "$cur"))
+    fi
+}
+complete -F @FUNC@ @CMD@
+`)
+}
+
+func zshCompletion(cmdName string, flags []completionFlag) string {
+	return completionReplacer(cmdName, flags).Replace(`#compdef @CMD@
+
+@FUNC@() {
+    local -a subcommands skills
+    subcommands=(@SUBCOMMANDS@)
+    case "$words[CURRENT-1]" in
+    --skills)
+        compadd -- ${(f)"$(@CMD@ completion --list-skills 2>/dev/null)"}
+        return
+        ;;
+    @VALUE_FLAGS@)
+        _files
+        return
+        ;;
+    esac
+    if (( CURRENT > 2 && ${subcommands[(Ie)$words[2]]} )); then
+        case "$words[2]" in
+        which|info)
+            [[ $words[CURRENT] != -* ]] && compadd -- ${(f)"$(@CMD@ completion --list-skills 2>/dev/null)"}
+            ;;
+        completion)
+            compadd -- bash zsh fish
+            ;;
+        *)
+            _files
+            ;;
+        esac
+        return
+    fi
+    if [[ $words[CURRENT] == -* ]]; then
+        compadd -- @FLAGS@
+        return
+    fi
+    skills=(${(f)"$(@CMD@ completion --list-skills 2>/dev/null)"})
+    if (( CURRENT == 2 )); then
+        compadd -- $subcommands $skills
+    else
+        compadd -- $skills
+    fi
+}
+
+if [[ "$funcstack[1]" == "@FUNC@" ]]; then
+    @FUNC@ "$@"
+else
+    compdef @FUNC@ @CMD@
+fi
+`)
+}
+
+func fishCompletion(cmdName string, flags []completionFlag) string {
+	var b strings.Builder
+	r := completionReplacer(cmdName, flags)
+	b.WriteString(r.Replace(`complete -c @CMD@ -f
+complete -c @CMD@ -n __fish_use_subcommand -a '@SUBCOMMANDS@'
+complete -c @CMD@ -n __fish_use_subcommand -a '(@CMD@ completion --list-skills 2>/dev/null)'
+complete -c @CMD@ -n '__fish_seen_subcommand_from which info' -a '(@CMD@ completion --list-skills 2>/dev/null)'
+complete -c @CMD@ -n '__fish_seen_subcommand_from completion' -a 'bash zsh fish'
+`))
+	for _, f := range flags {
+		option := "-l"
+		if len(f.name) == 1 {
+			option = "-s"
+		}
+		line := fmt.Sprintf("complete -c %s -n __fish_use_subcommand %s %s", cmdName, option, f.name)
+		switch {
+		case f.name == "skills":
+			line += fmt.Sprintf(" -r -a '(%s completion --list-skills 2>/dev/null)'", cmdName)
+		case f.takesValue:
+			line += " -r -F"
+		}
+		b.WriteString(line + " -d " + fishQuote(f.usage) + "\n")
+	}
+	return b.String()
+}
+
+func fishQuote(text string) string {
+	return "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(text) + "'"
+}
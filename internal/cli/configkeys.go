@@ -0,0 +1,185 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"agent-skills/internal/installer"
+
+	"github.com/BurntSushi/toml"
+)
+
+type configKey struct {
+	name     string
+	values   []string
+	foldCase bool
+	check    func(string) error
+	fallback string
+	get      func(appConfig) string
+}
+
+var configKeys = []configKey{
+	{name: "skill-repo-path", get: func(c appConfig) string { return c.SkillRepoPath }},
+	{name: "project-choice", values: []string{"skip", "cwd", "custom"}, get: func(c appConfig) string { return c.ProjectChoice }},
+	{name: "project-path", get: func(c appConfig) string { return c.ProjectPath }},
+	{name: "install-mode", values: []string{string(installer.ModeSymlink), string(installer.ModeCopy), string(installer.ModeHardlink)}, foldCase: true, get: func(c appConfig) string { return c.InstallMode }},
+	{name: "default-selection", values: []string{"all", "none"}, get: func(c appConfig) string { return c.DefaultSelection }},
+	{name: "description-file", fallback: installer.DefaultDescriptionFile, get: func(c appConfig) string { return c.DescriptionFile }},
+	{name: "refresh-cooldown", check: checkDuration, fallback: "15m", get: func(c appConfig) string { return c.RefreshCooldown }},
+	{name: "cache-ttl", check: checkDuration, fallback: "24h", get: func(c appConfig) string { return c.CacheTTL }},
+}
+
+func findConfigKey(name string) (configKey, error) {
+	for _, key := range configKeys {
+		if key.name == name {
+			return key, nil
+		}
+	}
+	return configKey{}, fmt.Errorf("unknown config key %q: want one of %s", name, configKeyNames())
+}
+
+func configKeyNames() string {
+	names := make([]string, 0, len(configKeys))
+	for _, key := range configKeys {
+		names = append(names, key.name)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
+
+func (k configKey) validate(value string) error {
+	if k.values != nil {
+		for _, allowed := range k.values {
+			if value == allowed || k.foldCase && strings.EqualFold(value, allowed) {
+				return nil
+			}
+		}
+		return fmt.Errorf("invalid %s %q: want %s", k.name, value, strings.Join(k.values, "|"))
+	}
+	if k.check != nil {
+		if err := k.check(value); err != nil {
+			return fmt.Errorf("invalid %s %q: %w", k.name, value, err)
+		}
+	}
+	return nil
+}
+
+func checkDuration(value string) error {
+	d, err := time.ParseDuration(value)
+	if err != nil || d < 0 {
+		return errors.New("want a duration such as 15m or 24h")
+	}
+	return nil
+}
+
+func runConfigGet(args []string, cmdName string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("usage: %s config get KEY", cmdName)
+	}
+	key, err := findConfigKey(args[0])
+	if err != nil {
+		return err
+	}
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	defaultRoot, _ := detectRepoRoot("")
+	cwd, _ := os.Getwd()
+	value := key.get(withDefaultConfig(cfg, defaultRoot, cwd))
+	if value == "" {
+		value = key.fallback
+	}
+	fmt.Println(value)
+	return nil
+}
+
+func runConfigSet(args []string, cmdName string) error {
+	if len(args) != 2 {
+		return fmt.Errorf("usage: %s config set KEY VALUE", cmdName)
+	}
+	key, err := findConfigKey(args[0])
+	if err != nil {
+		return err
+	}
+	value := strings.TrimSpace(args[1])
+	if err := key.validate(value); err != nil {
+		return err
+	}
+	path, err := configFilePath()
+	if err != nil {
+		return err
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		data, err = defaultConfigData()
+	}
+	if err != nil {
+		return err
+	}
+	updated := configWithValue(data, key.name, value)
+	var cfg appConfig
+	if _, err := toml.Decode(string(updated), &cfg); err != nil {
+		return fmt.Errorf("set %s: %w", key.name, err)
+	}
+	if err := validateConfig(cfg); err != nil {
+		return fmt.Errorf("set %s: %w; %s was not changed", key.name, err, path)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, updated, 0o644); err != nil {
+		return fmt.Errorf("save %s: %w", key.name, err)
+	}
+	fmt.Printf("Set %s = %q in %s\n", key.name, value, path)
+	return nil
+}
+
+func validateConfig(cfg appConfig) error {
+	for _, key := range configKeys {
+		if key.values == nil && key.check == nil {
+			continue
+		}
+		value := strings.TrimSpace(key.get(cfg))
+		if value == "" {
+			continue
+		}
+		if err := key.validate(value); err != nil {
+			return err
+		}
+	}
+	if cfg.ProjectChoice == "custom" && strings.TrimSpace(cfg.ProjectPath) == "" {
+		return errors.New(`project-choice = "custom" needs a non-empty project-path`)
+	}
+	return nil
+}
+
+func configFileKeys() []string {
+	t := reflect.TypeOf(appConfig{})
+	keys := make([]string, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		if tag := t.Field(i).Tag.Get("toml"); tag != "" {
+			keys = append(keys, tag)
+		}
+	}
+	return keys
+}
+
+func unknownConfigKeyWarning(key string) string {
+	best, bestDistance := "", 3
+	for _, known := range configFileKeys() {
+		if d := levenshtein(key, known); d < bestDistance {
+			best, bestDistance = known, d
+		}
+	}
+	if best != "" {
+		return fmt.Sprintf("unknown config key %q (did you mean %q?)", key, best)
+	}
+	return fmt.Sprintf("unknown config key %q", key)
+}
@@ -0,0 +1,70 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func useConfigFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.toml")
+	if content != "" {
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	previous := configPathOverride
+	configPathOverride = path
+	t.Cleanup(func() { configPathOverride = previous })
+	return path
+}
+
+func TestConfigWithValueKeepsComments(t *testing.T) {
+	data := []byte("# my skills\ninstall-mode = \"copy\"\n\n[[repos]]\nname = \"team\"\n")
+	got := string(configWithValue(data, "install-mode", "symlink"))
+	want := "# my skills\ninstall-mode = \"symlink\"\n\n[[repos]]\nname = \"team\"\n"
+	if got != want {
+		t.Fatalf("replace:\n%s\nwant:\n%s", got, want)
+	}
+	got = string(configWithValue(data, "cache-ttl", "1h"))
+	want = "# my skills\ninstall-mode = \"copy\"\ncache-ttl = \"1h\"\n\n[[repos]]\nname = \"team\"\n"
+	if got != want {
+		t.Fatalf("insert before table:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestRunConfigSetValidatesMergedConfig(t *testing.T) {
+	original := "# keep me\nproject-choice = \"cwd\"\n"
+	path := useConfigFile(t, original)
+
+	err := runConfigSet([]string{"project-choice", "custom"}, "askill")
+	if err == nil || !strings.Contains(err.Error(), "project-path") {
+		t.Fatalf("runConfigSet error = %v, want a project-path error", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != original {
+		t.Fatalf("config was rewritten after a failed set:\n%s", data)
+	}
+
+	if err := runConfigSet([]string{"project-path", "/work"}, "askill"); err != nil {
+		t.Fatal(err)
+	}
+	if err := runConfigSet([]string{"project-choice", "custom"}, "askill"); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := loadConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.ProjectChoice != "custom" || cfg.ProjectPath != "/work" {
+		t.Fatalf("config = %+v", cfg)
+	}
+	if data, _ := os.ReadFile(path); !strings.HasPrefix(string(data), "# keep me\n") {
+		t.Fatalf("comment lost:\n%s", data)
+	}
+}
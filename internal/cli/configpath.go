@@ -0,0 +1,35 @@
+package cli
+
+import (
+	"errors"
+	"strings"
+)
+
+var configPathOverride string
+
+func extractConfigPath(args []string) ([]string, error) {
+	kept := make([]string, 0, len(args))
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "--" {
+			return append(kept, args[i:]...), nil
+		}
+		switch {
+		case arg == "--config" || arg == "-config":
+			if i+1 >= len(args) || strings.TrimSpace(args[i+1]) == "" {
+				return nil, errors.New("--config needs a path")
+			}
+			configPathOverride = args[i+1]
+			i++
+		case strings.HasPrefix(arg, "--config=") || strings.HasPrefix(arg, "-config="):
+			_, value, _ := strings.Cut(arg, "=")
+			if strings.TrimSpace(value) == "" {
+				return nil, errors.New("--config needs a path")
+			}
+			configPathOverride = value
+		default:
+			kept = append(kept, arg)
+		}
+	}
+	return kept, nil
+}
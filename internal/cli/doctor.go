@@ -0,0 +1,83 @@
+package cli
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"agent-skills/internal/installer"
+)
+
+func runDoctorCommand(args []string, cmdName string) error {
+	fs := flag.NewFlagSet(cmdName+" doctor", flag.ContinueOnError)
+	fs.SetOutput(os.Stderr)
+	var projectPath string
+	fs.StringVar(&projectPath, "project", "", "also check project-local targets under this path")
+	fs.StringVar(&projectPath, "p", "", "alias for --project")
+	fs.Usage = func() {
+		out := fs.Output()
+		fmt.Fprintf(out, "Usage: %s doctor [options]\n\n", cmdName)
+		fmt.Fprintln(out, "Report dangling symlinks in all targets; exits non-zero if any are found.")
+		fmt.Fprintln(out)
+		fmt.Fprintln(out, "Options:")
+		tw := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(tw, "  -p, --project\tAlso check project-local targets under this path")
+		fmt.Fprintln(tw, "  -h, --help\tShow help")
+		_ = tw.Flush()
+	}
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return fmt.Errorf("determine home directory: %w", err)
+	}
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	subdirs, err := targetSubdirs(cfg)
+	if err != nil {
+		return err
+	}
+	var targets []installer.Target
+	for _, t := range installer.TargetTypes() {
+		if target, ok := installer.TargetForTypeWithSubdirs(t, homeDir, projectPath, subdirs); ok {
+			targets = append(targets, target)
+		}
+	}
+	targets = installer.DedupeTargetsByRealPath(targets)
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	dangling := 0
+	for _, target := range targets {
+		entries, err := installer.InstalledEntries(target)
+		if err != nil {
+			return fmt.Errorf("read target %s: %w", target.Path, err)
+		}
+		for _, entry := range entries {
+			if entry.Status != installer.EntryDangling {
+				continue
+			}
+			if dangling == 0 {
+				fmt.Fprintln(tw, "TARGET\tPATH\tLINK TARGET")
+			}
+			dangling++
+			fmt.Fprintf(tw, "%s\t%s\t%s\n", target.Type, entry.Path, entry.LinkTarget)
+		}
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	if dangling > 0 {
+		return fmt.Errorf("%d dangling symlink(s); reinstall or uninstall them", dangling)
+	}
+	fmt.Printf("No dangling symlinks in %d target(s)\n", len(targets))
+	return nil
+}
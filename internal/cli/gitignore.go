@@ -0,0 +1,56 @@
+package cli
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"agent-skills/internal/installer"
+)
+
+func gitignoreEntries(project, dest string, mode installer.Mode, layout installer.Layout) []string {
+	var entries []string
+	for _, path := range []string{dest, installer.MetaPath(dest)} {
+		rel, err := filepath.Rel(project, path)
+		if err != nil || strings.HasPrefix(rel, "..") {
+			return nil
+		}
+		entries = append(entries, "/"+filepath.ToSlash(rel))
+	}
+	if mode != installer.ModeSymlink && layout == installer.LayoutDir {
+		entries[0] += "/"
+	}
+	return entries
+}
+
+func ensureGitignoreEntries(project string, entries []string) (int, error) {
+	path := filepath.Join(project, ".gitignore")
+	data, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return 0, err
+	}
+	existing := make(map[string]bool)
+	for _, line := range strings.Split(string(data), "\n") {
+		existing[strings.TrimSpace(line)] = true
+	}
+	var b strings.Builder
+	added := 0
+	for _, entry := range entries {
+		if existing[entry] {
+			continue
+		}
+		existing[entry] = true
+		b.WriteString(entry)
+		b.WriteString("\n")
+		added++
+	}
+	if added == 0 {
+		return 0, nil
+	}
+	content := string(data)
+	if content != "" && !strings.HasSuffix(content, "\n") {
+		content += "\n"
+	}
+	return added, os.WriteFile(path, []byte(content+b.String()), 0o644)
+}
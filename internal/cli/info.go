@@ -0,0 +1,175 @@
+package cli
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"agent-skills/internal/installer"
+)
+
+type skillInfo struct {
+	Name        string          `json:"name"`
+	Description string          `json:"description"`
+	Path        string          `json:"path"`
+	Namespace   string          `json:"namespace,omitempty"`
+	Version     string          `json:"version,omitempty"`
+	Category    string          `json:"category,omitempty"`
+	Tags        []string        `json:"tags,omitempty"`
+	Aliases     []string        `json:"aliases,omitempty"`
+	DependsOn   []string        `json:"depends_on,omitempty"`
+	Enabled     bool            `json:"enabled"`
+	Frontmatter string          `json:"frontmatter"`
+	Installed   []skillLocation `json:"installed"`
+}
+
+func runInfoCommand(args []string, cmdName string) error {
+	fs := flag.NewFlagSet(cmdName+" info", flag.ContinueOnError)
+	fs.SetOutput(os.Stderr)
+	var repoRoot string
+	var projectPath string
+	var jsonOutput bool
+	fs.StringVar(&repoRoot, "repo", "", "path to skills repo, a .zip of one, or a git URL")
+	fs.StringVar(&repoRoot, "r", "", "alias for --repo")
+	fs.StringVar(&projectPath, "project", "", "also check project-local targets under this path")
+	fs.StringVar(&projectPath, "p", "", "alias for --project")
+	fs.BoolVar(&jsonOutput, "json", false, "print JSON instead of text")
+	fs.Usage = func() {
+		out := fs.Output()
+		fmt.Fprintf(out, "Usage: %s info [options] SKILL\n\n", cmdName)
+		fmt.Fprintln(out, "Show a skill's metadata, full frontmatter, and the targets it is installed to.")
+		fmt.Fprintln(out)
+		fmt.Fprintln(out, "Options:")
+		tw := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(tw, "  -r, --repo\tPath to skills repo, a .zip of one, or a git URL (defaults to config)")
+		fmt.Fprintln(tw, "  -p, --project\tAlso check project-local targets under this path")
+		fmt.Fprintln(tw, "  --json\tPrint JSON instead of text")
+		fmt.Fprintln(tw, "  -h, --help\tShow help")
+		_ = tw.Flush()
+	}
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return errors.New("info takes exactly one skill name")
+	}
+	name := fs.Arg(0)
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	skills, cleanup, err := listSkills(repoRoot, cfg)
+	if err != nil {
+		return err
+	}
+	if cleanup != nil {
+		defer cleanup()
+	}
+	var skill installer.Skill
+	found := false
+	for _, candidate := range skills {
+		if skillMatches(candidate, name) {
+			skill, found = candidate, true
+			break
+		}
+	}
+	if !found {
+		if suggestion, ok := closestSkillName(skills, name); ok {
+			return fmt.Errorf("no skill named %s in the skills repo; did you mean %s?", name, suggestion)
+		}
+		return fmt.Errorf("no skill named %s in the skills repo", name)
+	}
+
+	info := skillInfo{
+		Name:        skill.Name,
+		Description: skill.Description,
+		Path:        skill.Path,
+		Namespace:   skill.Namespace,
+		Version:     skill.Version,
+		Category:    skill.Category,
+		Tags:        skill.Tags,
+		Aliases:     skill.Aliases,
+		DependsOn:   skill.DependsOn,
+		Enabled:     skill.Enabled,
+		Installed:   []skillLocation{},
+	}
+	if lines, err := installer.FrontmatterBlock(skillFiles([]installer.Skill{skill})[0]); err != nil {
+		fmt.Fprintf(os.Stderr, "warning: read frontmatter of %s: %v\n", skill.Name, err)
+	} else {
+		info.Frontmatter = strings.Join(lines, "\n")
+	}
+
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return fmt.Errorf("determine home directory: %w", err)
+	}
+	targets, err := discoverTargets(homeDir, projectPath, cfg, true)
+	if err != nil {
+		return err
+	}
+	for _, target := range targets {
+		if location, ok := locateSkill(target, skill); ok {
+			info.Installed = append(info.Installed, location)
+		}
+	}
+
+	if jsonOutput {
+		return printJSON(info)
+	}
+	tw := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintf(tw, "Name:\t%s\n", info.Name)
+	fmt.Fprintf(tw, "Description:\t%s\n", info.Description)
+	fmt.Fprintf(tw, "Path:\t%s\n", info.Path)
+	if info.Namespace != "" {
+		fmt.Fprintf(tw, "Namespace:\t%s\n", info.Namespace)
+	}
+	fmt.Fprintf(tw, "Version:\t%s\n", versionLabel(info.Version))
+	if info.Category != "" {
+		fmt.Fprintf(tw, "Category:\t%s\n", info.Category)
+	}
+	if len(info.Tags) > 0 {
+		fmt.Fprintf(tw, "Tags:\t%s\n", strings.Join(info.Tags, ", "))
+	}
+	if len(info.Aliases) > 0 {
+		fmt.Fprintf(tw, "Aliases:\t%s\n", strings.Join(info.Aliases, ", "))
+	}
+	if len(info.DependsOn) > 0 {
+		fmt.Fprintf(tw, "Depends on:\t%s\n", strings.Join(info.DependsOn, ", "))
+	}
+	fmt.Fprintf(tw, "Enabled:\t%t\n", info.Enabled)
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	if info.Frontmatter != "" {
+		fmt.Println()
+		fmt.Println("Frontmatter:")
+		fmt.Println("  ---")
+		for _, line := range strings.Split(info.Frontmatter, "\n") {
+			fmt.Println("  " + line)
+		}
+		fmt.Println("  ---")
+	}
+	fmt.Println()
+	if len(info.Installed) == 0 {
+		fmt.Printf("%s is not installed in any target\n", info.Name)
+		return nil
+	}
+	tw = tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "TARGET\tPATH\tMODE\tINSTALLED\tSTATUS")
+	for _, location := range info.Installed {
+		status := location.Status
+		if status == versionBehind {
+			status = warningStyle.Render(status)
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", location.Target, location.Path, location.Mode, versionLabel(location.InstalledVersion), status)
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,99 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"agent-skills/internal/installer"
+)
+
+type installedSkill struct {
+	Target  installer.TargetType `json:"target"`
+	Skill   string               `json:"skill"`
+	Mode    installer.Mode       `json:"mode"`
+	Layout  installer.Layout     `json:"layout"`
+	Source  string               `json:"source"`
+	Version string               `json:"version"`
+	Path    string               `json:"path"`
+}
+
+func runInstalledCommand(args []string, cmdName string) error {
+	fs := flag.NewFlagSet(cmdName+" installed", flag.ContinueOnError)
+	fs.SetOutput(os.Stderr)
+	var projectPath string
+	var jsonOutput bool
+	fs.StringVar(&projectPath, "project", "", "also list project-local targets under this path")
+	fs.StringVar(&projectPath, "p", "", "alias for --project")
+	fs.BoolVar(&jsonOutput, "json", false, "print a JSON array instead of a table")
+	fs.Usage = func() {
+		out := fs.Output()
+		fmt.Fprintf(out, "Usage: %s installed [options]\n\n", cmdName)
+		fmt.Fprintln(out, "List askill-managed skills across all targets.")
+		fmt.Fprintln(out)
+		fmt.Fprintln(out, "Options:")
+		tw := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(tw, "  -p, --project\tAlso list project-local targets under this path")
+		fmt.Fprintln(tw, "  --json\tPrint a JSON array instead of a table")
+		fmt.Fprintln(tw, "  -h, --help\tShow help")
+		_ = tw.Flush()
+	}
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return fmt.Errorf("determine home directory: %w", err)
+	}
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	targets, err := discoverTargets(homeDir, projectPath, cfg, true)
+	if err != nil {
+		return err
+	}
+	entries, err := managedEntries(targets)
+	if err != nil {
+		return err
+	}
+
+	installed := make([]installedSkill, 0, len(entries))
+	for _, entry := range entries {
+		item := installedSkill{Target: entry.target.Type, Skill: entry.name, Path: entry.dest}
+		if meta, err := installer.ReadMeta(entry.dest); err == nil {
+			item.Mode = meta.Mode
+			item.Layout = meta.Layout
+			item.Source = meta.Source
+			item.Version = meta.Version
+		}
+		installed = append(installed, item)
+	}
+
+	if jsonOutput {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(installed)
+	}
+	if len(installed) == 0 {
+		fmt.Println("No managed skills installed")
+		return nil
+	}
+	tw := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "TARGET\tSKILL\tMODE\tVERSION\tSOURCE\tPATH")
+	for _, item := range installed {
+		version := item.Version
+		if version == "" {
+			version = "-"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n", item.Target, item.Skill, item.Mode, version, item.Source, item.Path)
+	}
+	return tw.Flush()
+}
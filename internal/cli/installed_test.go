@@ -0,0 +1,46 @@
+package cli
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestInstalledShowsVersion(t *testing.T) {
+	repo := t.TempDir()
+	writeTestFile(t, filepath.Join(repo, "skills", "review", "SKILL.md"), "---\nname: review\nversion: 1.4.0\n---\n")
+	writeTestFile(t, filepath.Join(repo, "skills", "plain", "SKILL.md"), "---\nname: plain\n---\n")
+	home := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(home, ".claude", "skills"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := runAskill(t, home, "--repo", repo, "--target", "claude-global", "--copy", "--yes"); err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := runAskill(t, home, "installed", "--json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var installed []installedSkill
+	if err := json.Unmarshal([]byte(out), &installed); err != nil {
+		t.Fatalf("%v\n%s", err, out)
+	}
+	versions := make(map[string]string)
+	for _, item := range installed {
+		versions[item.Skill] = item.Version
+	}
+	if versions["review"] != "1.4.0" || versions["plain"] != "" {
+		t.Fatalf("versions = %v, want review at 1.4.0 and plain unversioned", versions)
+	}
+
+	out, err = runAskill(t, home, "installed")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out, "VERSION") || !strings.Contains(out, "1.4.0") {
+		t.Fatalf("table = %q, want a VERSION column", out)
+	}
+}
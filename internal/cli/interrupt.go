@@ -0,0 +1,82 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+)
+
+type cleanupEntry struct {
+	id int
+	fn func()
+}
+
+var cleanups struct {
+	mu      sync.Mutex
+	nextID  int
+	entries []cleanupEntry
+}
+
+func registerCleanup(fn func()) func() {
+	cleanups.mu.Lock()
+	cleanups.nextID++
+	id := cleanups.nextID
+	cleanups.entries = append(cleanups.entries, cleanupEntry{id: id, fn: fn})
+	cleanups.mu.Unlock()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			if unregisterCleanup(id) {
+				fn()
+			}
+		})
+	}
+}
+
+func unregisterCleanup(id int) bool {
+	cleanups.mu.Lock()
+	defer cleanups.mu.Unlock()
+	for i, entry := range cleanups.entries {
+		if entry.id == id {
+			cleanups.entries = append(cleanups.entries[:i], cleanups.entries[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+func runCleanups() {
+	cleanups.mu.Lock()
+	entries := cleanups.entries
+	cleanups.entries = nil
+	cleanups.mu.Unlock()
+	for i := len(entries) - 1; i >= 0; i-- {
+		entries[i].fn()
+	}
+}
+
+func handleInterrupts() func() {
+	signals := make(chan os.Signal, 1)
+	done := make(chan struct{})
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		select {
+		case sig := <-signals:
+			runCleanups()
+			fmt.Fprintf(os.Stderr, "\ninterrupted (%s)\n", sig)
+			code := 130
+			if sig == syscall.SIGTERM {
+				code = 143
+			}
+			os.Exit(code)
+		case <-done:
+		}
+	}()
+	return func() {
+		signal.Stop(signals)
+		close(done)
+	}
+}
@@ -0,0 +1,69 @@
+package cli
+
+import (
+	"bufio"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestRunCleanupsSkipsFinishedEntries(t *testing.T) {
+	var order []string
+	first := registerCleanup(func() { order = append(order, "first") })
+	done := registerCleanup(func() { order = append(order, "done") })
+	registerCleanup(func() { order = append(order, "last") })
+	done()
+	runCleanups()
+	first()
+	if want := []string{"done", "last", "first"}; !reflect.DeepEqual(order, want) {
+		t.Fatalf("cleanup order = %v, want %v", order, want)
+	}
+}
+
+func TestInterruptRunsCleanups(t *testing.T) {
+	if dir := os.Getenv("ASKILL_TEST_INTERRUPT_DIR"); dir != "" {
+		handleInterrupts()
+		registerCleanup(func() { _ = os.RemoveAll(dir) })
+		os.Stdout.WriteString("ready\n")
+		time.Sleep(10 * time.Second)
+		os.Exit(0)
+	}
+
+	if runtime.GOOS == "windows" {
+		t.Skip("signals cannot be sent to processes on windows")
+	}
+	dir := filepath.Join(t.TempDir(), "askill-repo-test")
+	if err := os.Mkdir(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	cmd := exec.Command(os.Args[0], "-test.run=^TestInterruptRunsCleanups$")
+	cmd.Env = append(os.Environ(), "ASKILL_TEST_INTERRUPT_DIR="+dir)
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := cmd.Start(); err != nil {
+		t.Fatal(err)
+	}
+	if line, err := bufio.NewReader(stdout).ReadString('\n'); err != nil || line != "ready\n" {
+		_ = cmd.Process.Kill()
+		t.Fatalf("helper did not start: %q, %v", line, err)
+	}
+	if err := cmd.Process.Signal(syscall.SIGTERM); err != nil {
+		t.Fatal(err)
+	}
+	err = cmd.Wait()
+	var exitErr *exec.ExitError
+	if !errors.As(err, &exitErr) || exitErr.ExitCode() != 143 {
+		t.Fatalf("helper exit = %v, want status 143", err)
+	}
+	if _, err := os.Stat(dir); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("temp dir survived the signal: %v", err)
+	}
+}
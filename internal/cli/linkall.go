@@ -0,0 +1,62 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"agent-skills/internal/installer"
+)
+
+func linkAllTargets(targets []installer.Target, skillsRoot string, report *reporter, dryRun bool) error {
+	skillsRoot = absDir(skillsRoot)
+	if dryRun {
+		for _, target := range targets {
+			state := installer.StateDrifted
+			if installer.IsSymlinkTo(target.Path, skillsRoot) {
+				state = installer.StateCurrent
+			} else if _, err := os.Lstat(target.Path); err != nil {
+				state = installer.StateMissing
+			}
+			fmt.Printf("%s\tall skills\t%s\t%s\n", state, target.Type, target.Path)
+		}
+		return nil
+	}
+	for _, target := range targets {
+		result := installResult{
+			Skill:  "all skills",
+			Target: target.Type,
+			Path:   target.Path,
+			Action: actionInstalled,
+			Mode:   installer.ModeSymlink,
+			label:  target.Label,
+		}
+		if _, err := os.Lstat(target.Path); err == nil {
+			result.Action = actionOverwritten
+		}
+		if err := installer.LinkSkillsRoot(skillsRoot, target.Path); err != nil {
+			return report.fail(result, fmt.Errorf("link %s to %s: %w", skillsRoot, target.Label, err))
+		}
+		meta := installer.Meta{Skill: installer.AllSkills, Source: skillsRoot, Mode: installer.ModeSymlink, Layout: installer.LayoutRoot}
+		if err := installer.WriteMeta(target.Path, meta); err != nil {
+			return report.fail(result, fmt.Errorf("write meta for %s: %w", target.Path, err))
+		}
+		report.record(result)
+	}
+	if report.terse() {
+		report.printSummary()
+	}
+	return nil
+}
+
+func linkedRoot(target installer.Target) (installer.Meta, bool) {
+	meta, err := installer.ReadMeta(target.Path)
+	if err != nil || meta.Layout != installer.LayoutRoot {
+		return installer.Meta{}, false
+	}
+	return meta, true
+}
+
+func linkedSkillPath(root string, skill installer.Skill) string {
+	return filepath.Join(root, filepath.FromSlash(skill.Namespace), filepath.Base(skill.Path))
+}
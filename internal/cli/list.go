@@ -0,0 +1,168 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+
+	"agent-skills/internal/installer"
+)
+
+type listedSkill struct {
+	Name        string                                  `json:"name"`
+	Description string                                  `json:"description"`
+	Path        string                                  `json:"path"`
+	Enabled     bool                                    `json:"enabled"`
+	Installed   map[installer.TargetType]installer.Mode `json:"installed"`
+}
+
+func runListCommand(args []string, cmdName string) error {
+	fs := flag.NewFlagSet(cmdName+" list", flag.ContinueOnError)
+	fs.SetOutput(os.Stderr)
+	var repoRoot string
+	var projectPath string
+	var jsonOutput bool
+	fs.StringVar(&repoRoot, "repo", "", "path to skills repo, a .zip of one, or a git URL")
+	fs.StringVar(&repoRoot, "r", "", "alias for --repo")
+	fs.StringVar(&projectPath, "project", "", "also check project-local targets under this path")
+	fs.StringVar(&projectPath, "p", "", "alias for --project")
+	fs.BoolVar(&jsonOutput, "json", false, "print a JSON array instead of a table")
+	fs.Usage = func() {
+		out := fs.Output()
+		fmt.Fprintf(out, "Usage: %s list [options]\n\n", cmdName)
+		fmt.Fprintln(out, "List discovered skills and where they are installed.")
+		fmt.Fprintln(out)
+		fmt.Fprintln(out, "Options:")
+		tw := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(tw, "  -r, --repo\tPath to skills repo, a .zip of one, or a git URL (defaults to config)")
+		fmt.Fprintln(tw, "  -p, --project\tAlso check project-local targets under this path")
+		fmt.Fprintln(tw, "  --json\tPrint a JSON array instead of a table")
+		fmt.Fprintln(tw, "  -h, --help\tShow help")
+		_ = tw.Flush()
+	}
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	skills, cleanup, err := listSkills(repoRoot, cfg)
+	if err != nil {
+		return err
+	}
+	if cleanup != nil {
+		defer cleanup()
+	}
+
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return fmt.Errorf("determine home directory: %w", err)
+	}
+	targets, err := discoverTargets(homeDir, projectPath, cfg, true)
+	if err != nil {
+		return err
+	}
+
+	listed := make([]listedSkill, 0, len(skills))
+	for _, skill := range skills {
+		item := listedSkill{
+			Name:        skill.Name,
+			Description: skill.Description,
+			Path:        skill.Path,
+			Enabled:     skill.Enabled,
+			Installed:   make(map[installer.TargetType]installer.Mode),
+		}
+		for _, target := range targets {
+			if mode, ok := installedMode(target, skill); ok {
+				item.Installed[target.Type] = mode
+			}
+		}
+		listed = append(listed, item)
+	}
+
+	if jsonOutput {
+		return printJSON(listed)
+	}
+	if len(listed) == 0 {
+		fmt.Println("No skills found")
+		return nil
+	}
+	tw := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	header := []string{"NAME", "DESCRIPTION"}
+	for _, target := range targets {
+		header = append(header, strings.ToUpper(string(target.Type)))
+	}
+	fmt.Fprintln(tw, strings.Join(header, "\t"))
+	for _, item := range listed {
+		name := item.Name
+		if !item.Enabled {
+			name += " (disabled)"
+		}
+		row := []string{name, item.Description}
+		for _, target := range targets {
+			status := "-"
+			if mode, ok := item.Installed[target.Type]; ok {
+				status = string(mode)
+			}
+			row = append(row, status)
+		}
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+	return tw.Flush()
+}
+
+func listSkills(repo string, cfg appConfig) ([]installer.Skill, func(), error) {
+	if info, err := os.Stat(repo); err == nil && info.Mode().IsRegular() && strings.EqualFold(filepath.Ext(repo), ".zip") {
+		skills, err := installer.DiscoverZipSkills(repo)
+		if err != nil {
+			return nil, nil, fmt.Errorf("discover skills: %w", err)
+		}
+		return skills, nil, nil
+	}
+	root, cleanup, err := resolveRepoRoot(context.Background(), cloneOptions{}, repo)
+	if err != nil {
+		return nil, nil, err
+	}
+	skills, err := installer.DiscoverSkillsWithDescriptionFile(filepath.Join(root, "skills"), descriptionFile(cfg))
+	if err != nil {
+		if cleanup != nil {
+			cleanup()
+		}
+		return nil, nil, fmt.Errorf("discover skills: %w", err)
+	}
+	return skills, cleanup, nil
+}
+
+func installedMode(target installer.Target, skill installer.Skill) (installer.Mode, bool) {
+	if meta, ok := linkedRoot(target); ok {
+		if _, err := os.Lstat(linkedSkillPath(meta.Source, skill)); err == nil {
+			return meta.Mode, true
+		}
+		return "", false
+	}
+	for _, layout := range []installer.Layout{installer.LayoutDir, installer.LayoutFlat} {
+		dest := skillDestination(target, skill, layout, destOptions{})
+		info, err := os.Lstat(dest)
+		if err != nil {
+			continue
+		}
+		if meta, err := installer.ReadMeta(dest); err == nil && meta.Mode != "" {
+			return meta.Mode, true
+		}
+		if info.Mode()&os.ModeSymlink != 0 {
+			return installer.ModeSymlink, true
+		}
+		return installer.ModeCopy, true
+	}
+	return "", false
+}
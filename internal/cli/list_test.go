@@ -0,0 +1,80 @@
+package cli
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"agent-skills/internal/installer"
+)
+
+func TestListMarksDisabledSkills(t *testing.T) {
+	repo := testSkillsRepo(t, "active")
+	writeTestFile(t, filepath.Join(repo, "skills", "parked", "SKILL.md"), "---\nname: parked\ndisabled: true\n---\n")
+	home := t.TempDir()
+
+	out, err := runAskill(t, home, "list", "--repo", repo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out, "parked (disabled)") || strings.Contains(out, "active (disabled)") {
+		t.Errorf("list output does not mark only the disabled skill:\n%s", out)
+	}
+
+	out, err = runAskill(t, home, "list", "--repo", repo, "--json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var listed []listedSkill
+	if err := json.Unmarshal([]byte(out), &listed); err != nil {
+		t.Fatal(err)
+	}
+	enabled := map[string]bool{}
+	for _, item := range listed {
+		enabled[item.Name] = item.Enabled
+	}
+	if len(enabled) != 2 || !enabled["active"] || enabled["parked"] {
+		t.Errorf("listed = %+v, want active enabled and parked disabled", listed)
+	}
+}
+
+func TestListReadsZipWithoutExtracting(t *testing.T) {
+	for name, prefix := range map[string]string{"repo at root": "", "one top-level folder": "team-skills-1.2/"} {
+		t.Run(name, func(t *testing.T) {
+			zipPath := writeZip(t, []archiveEntry{
+				{name: prefix + "README.md", body: "readme"},
+				{name: prefix + "skills/review/SKILL.md", body: "---\nname: review\ndescription: Reviews pull requests\n---\n"},
+				{name: prefix + "skills/review/refs/guide.md", body: "guide"},
+				{name: prefix + "skills/tools/lint/SKILL.md", body: "---\nname: lint\n---\n"},
+			})
+			tmp := t.TempDir()
+			t.Setenv("TMPDIR", tmp)
+
+			skills, cleanup, err := listSkills(zipPath, appConfig{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if cleanup != nil {
+				t.Error("listing a zip returned a cleanup, so it extracted the archive")
+			}
+			names := map[string]string{}
+			for _, skill := range skills {
+				names[skill.Name] = installer.InstallName(skill)
+			}
+			if len(names) != 2 || names["review"] != "review" || names["lint"] != "tools-lint" {
+				t.Fatalf("listed %+v", skills)
+			}
+
+			home := t.TempDir()
+			out, err := runAskill(t, home, "search", "--repo", zipPath, "pull requests")
+			if err != nil || !strings.Contains(out, "review") {
+				t.Errorf("search over the zip = %q, %v", out, err)
+			}
+			if entries, err := os.ReadDir(tmp); err != nil || len(entries) != 0 {
+				t.Errorf("temp dir holds %v (%v), want nothing extracted", entries, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,337 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+
+	"agent-skills/internal/installer"
+)
+
+const lockfileVersion = 1
+
+type lockfile struct {
+	Version int           `toml:"version"`
+	Project string        `toml:"project,omitempty"`
+	Skills  []lockedSkill `toml:"skills"`
+}
+
+type lockedSkill struct {
+	Name   string               `toml:"name"`
+	Source string               `toml:"source"`
+	Commit string               `toml:"commit,omitempty"`
+	Path   string               `toml:"path"`
+	Target installer.TargetType `toml:"target"`
+	Mode   installer.Mode       `toml:"mode"`
+	Layout installer.Layout     `toml:"layout"`
+	Dest   string               `toml:"dest"`
+}
+
+type lockSource struct {
+	root   string
+	source string
+	commit string
+}
+
+func readLockfile(path string) (lockfile, error) {
+	var lock lockfile
+	if _, err := toml.DecodeFile(path, &lock); err != nil {
+		return lockfile{}, fmt.Errorf("read lockfile %s: %w", path, err)
+	}
+	if lock.Version != lockfileVersion {
+		return lockfile{}, fmt.Errorf("lockfile %s: unsupported version %d (want %d)", path, lock.Version, lockfileVersion)
+	}
+	for i, skill := range lock.Skills {
+		if skill.Name == "" || skill.Source == "" || skill.Path == "" || skill.Dest == "" {
+			return lockfile{}, fmt.Errorf("lockfile %s: skills[%d] needs name, source, path, and dest", path, i)
+		}
+		if !installer.IsTargetType(string(skill.Target)) {
+			return lockfile{}, fmt.Errorf("lockfile %s: skills[%d] has unknown target %q", path, i, skill.Target)
+		}
+		if skill.Mode != installer.ModeCopy && skill.Mode != installer.ModeSymlink && skill.Mode != installer.ModeHardlink {
+			return lockfile{}, fmt.Errorf("lockfile %s: skills[%d] has unknown mode %q", path, i, skill.Mode)
+		}
+	}
+	return lock, nil
+}
+
+func writeLockfile(path string, lock lockfile) error {
+	var b bytes.Buffer
+	if err := toml.NewEncoder(&b).Encode(lock); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("create lockfile dir: %w", err)
+	}
+	if err := os.WriteFile(path, b.Bytes(), 0o644); err != nil {
+		return fmt.Errorf("write lockfile: %w", err)
+	}
+	return nil
+}
+
+func lockSources(ctx context.Context, roots []string) []lockSource {
+	sources := make([]lockSource, 0, len(roots))
+	for _, root := range roots {
+		source := lockSource{root: absDir(root), source: absDir(root)}
+		if !isGitToplevel(ctx, root) {
+			sources = append(sources, source)
+			continue
+		}
+		if origin, err := gitOutput(ctx, root, "remote", "get-url", "origin"); err == nil && origin != "" {
+			source.source = origin
+		}
+		if commit, err := gitOutput(ctx, root, "rev-parse", "HEAD"); err == nil {
+			source.commit = commit
+		}
+		sources = append(sources, source)
+	}
+	return sources
+}
+
+func lockEntry(sources []lockSource, item planItem, mode installer.Mode) (lockedSkill, error) {
+	source, rel, ok := sourceFor(sources, item.skill.Path)
+	if !ok {
+		return lockedSkill{}, fmt.Errorf("lockfile: %s is outside the skill repos", item.skill.Path)
+	}
+	return lockedSkill{
+		Name:   item.skill.Name,
+		Source: source.source,
+		Commit: source.commit,
+		Path:   rel,
+		Target: item.target.Type,
+		Mode:   mode,
+		Layout: item.layout,
+		Dest:   filepath.Base(item.dest),
+	}, nil
+}
+
+func sourceFor(sources []lockSource, skillPath string) (lockSource, string, bool) {
+	path := absDir(skillPath)
+	for _, source := range sources {
+		rel, err := filepath.Rel(source.root, path)
+		if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			continue
+		}
+		return source, filepath.ToSlash(rel), true
+	}
+	return lockSource{}, "", false
+}
+
+func writeInstallLockfile(ctx context.Context, path string, roots []string, project string, items []planItem, mode installer.Mode) error {
+	sources := lockSources(ctx, roots)
+	lock := lockfile{Version: lockfileVersion, Skills: []lockedSkill{}}
+	for _, item := range items {
+		entry, err := lockEntry(sources, item, mode)
+		if err != nil {
+			return err
+		}
+		if installer.IsProjectTarget(item.target.Type) {
+			lock.Project = absDir(project)
+		}
+		lock.Skills = append(lock.Skills, entry)
+	}
+	return writeLockfile(path, lock)
+}
+
+func installFromLockfile(ctx context.Context, clone cloneOptions, path, homeDir, projectPath string, cfg appConfig, report *reporter, dryRun, yes bool) (err error) {
+	lock, err := readLockfile(path)
+	if err != nil {
+		return err
+	}
+	if projectPath == "" {
+		projectPath = lock.Project
+	}
+	subdirs, err := targetSubdirs(cfg)
+	if err != nil {
+		return err
+	}
+
+	roots := make(map[string]string)
+	for _, skill := range lock.Skills {
+		key := skill.Source + "@" + skill.Commit
+		if _, ok := roots[key]; ok {
+			continue
+		}
+		root, cleanup, err := resolveLockedSource(ctx, clone, skill.Source, skill.Commit, report)
+		if err != nil {
+			return err
+		}
+		if cleanup != nil {
+			defer cleanup()
+		}
+		roots[key] = root
+	}
+
+	journal := newInstallJournal()
+	defer func() {
+		journal.finish(ctx, err, report)
+	}()
+	for _, skill := range lock.Skills {
+		target, ok := installer.TargetForTypeWithSubdirs(skill.Target, homeDir, projectPath, subdirs)
+		if !ok {
+			return fmt.Errorf("lockfile: %s needs a project path; pass --project", skill.Target)
+		}
+		src := filepath.Join(roots[skill.Source+"@"+skill.Commit], filepath.FromSlash(skill.Path))
+		dest := filepath.Join(target.Path, skill.Dest)
+		if dryRun {
+			state := installer.CheckInstalled(lockedSkillSource(src, skill.Layout), dest, skill.Mode, skill.Layout)
+			fmt.Printf("%s\t%s\t%s\t%s\n", state, skill.Name, target.Type, dest)
+			continue
+		}
+		result := installResult{
+			Skill:  skill.Name,
+			Target: target.Type,
+			Path:   dest,
+			Action: actionInstalled,
+			Mode:   skill.Mode,
+			label:  target.Label,
+		}
+		if skill.Mode == installer.ModeSymlink && skill.Layout != installer.LayoutFlat && installer.IsSymlinkTo(dest, src) {
+			result.Action = actionSkipped
+			result.upToDate = true
+			report.record(result)
+			continue
+		}
+		if info, err := os.Lstat(dest); err == nil {
+			if !yes && !confirm(stdinReader, overwritePrompt(dest, info, target.Label, skill.Mode)) {
+				result.Action = actionSkipped
+				report.record(result)
+				continue
+			}
+			result.Action = actionOverwritten
+		}
+		if err := os.MkdirAll(target.Path, 0o755); err != nil {
+			return report.fail(result, fmt.Errorf("create target %s: %w", target.Path, err))
+		}
+		if err := journal.prepare(ctx, dest, false); err != nil {
+			return report.fail(result, err)
+		}
+		var installErr error
+		if skill.Layout == installer.LayoutFlat {
+			src = lockedSkillSource(src, skill.Layout)
+			installErr = installer.InstallSkillFile(src, dest, skill.Mode)
+		} else {
+			installErr = installer.InstallSkillContext(ctx, src, dest, skill.Mode)
+		}
+		if installErr == nil {
+			installErr = installer.VerifyInstall(dest, skill.Layout)
+		}
+		if installErr != nil {
+			return report.fail(result, fmt.Errorf("install %s to %s: %w", skill.Name, target.Label, installErr))
+		}
+		meta := installer.Meta{Skill: skill.Name, Source: src, Mode: skill.Mode, Layout: skill.Layout}
+		if err := installer.WriteMeta(dest, meta); err != nil {
+			return report.fail(result, fmt.Errorf("write meta for %s: %w", dest, err))
+		}
+		journal.complete()
+		report.record(result)
+	}
+	if dryRun {
+		return nil
+	}
+	if report.terse() {
+		report.printSummary()
+	}
+	return nil
+}
+
+func resolveLockedSource(ctx context.Context, clone cloneOptions, source, commit string, report *reporter) (string, func(), error) {
+	if installer.ExistsDir(source) {
+		if commit != "" {
+			if head, err := gitOutput(ctx, source, "rev-parse", "HEAD"); err == nil && head != commit {
+				report.warnf("%s is at %s, lockfile pins %s", source, head, commit)
+			}
+		}
+		return source, nil, nil
+	}
+	if commit == "" {
+		return cloneRepo(ctx, clone, source)
+	}
+	return cloneAtCommit(ctx, clone, normalizeRepoURL(source), commit)
+}
+
+func cloneAtCommit(ctx context.Context, clone cloneOptions, repoURL, commit string) (string, func(), error) {
+	clone = clone.forRepo(repoURL)
+	if dir, ok := usableCacheDir(clone, repoURL+"@"+commit); ok {
+		if head, err := gitOutput(ctx, dir, "rev-parse", "HEAD"); err == nil && strings.HasPrefix(head, commit) && isGitToplevel(ctx, dir) {
+			return dir, nil, nil
+		}
+		if err := os.RemoveAll(dir); err != nil {
+			return "", nil, err
+		}
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return "", nil, err
+		}
+		if err := clonePinned(ctx, clone, repoURL, commit, dir); err != nil {
+			_ = os.RemoveAll(dir)
+			return "", nil, err
+		}
+		return dir, nil, nil
+	}
+	tempDir, err := os.MkdirTemp("", "askill-repo-*")
+	if err != nil {
+		return "", nil, err
+	}
+	cleanup := registerCleanup(func() { _ = os.RemoveAll(tempDir) })
+	if err := clonePinned(ctx, clone, repoURL, commit, tempDir); err != nil {
+		cleanup()
+		return "", nil, err
+	}
+	return tempDir, cleanup, nil
+}
+
+func clonePinned(ctx context.Context, clone cloneOptions, repoURL, commit, dir string) error {
+	fetch := []string{"-C", dir, "fetch", "-q", "--depth", "1", "origin", commit}
+	checkout := "FETCH_HEAD"
+	if len(commit) < 40 {
+		fetch = []string{"-C", dir, "fetch", "-q", "origin"}
+		checkout = commit
+	}
+	for _, args := range [][]string{
+		{"-C", dir, "init", "-q"},
+		{"-C", dir, "remote", "add", "origin", repoURL},
+		fetch,
+		{"-C", dir, "checkout", "-q", "--detach", checkout},
+	} {
+		if err := runGit(ctx, clone, args...); err != nil {
+			return fmt.Errorf("clone %s at %s: %w", repoURL, commit, err)
+		}
+	}
+	return nil
+}
+
+func isGitToplevel(ctx context.Context, dir string) bool {
+	top, err := gitOutput(ctx, dir, "rev-parse", "--show-toplevel")
+	if err != nil {
+		return false
+	}
+	resolvedTop, errTop := filepath.EvalSymlinks(top)
+	resolvedDir, errDir := filepath.EvalSymlinks(dir)
+	return errTop == nil && errDir == nil && resolvedTop == resolvedDir
+}
+
+func gitOutput(ctx context.Context, dir string, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, "git", append([]string{"-C", dir}, args...)...)
+	out, err := cmd.Output()
+	return strings.TrimSpace(string(out)), err
+}
+
+func absDir(path string) string {
+	if abs, err := filepath.Abs(path); err == nil {
+		return abs
+	}
+	return filepath.Clean(path)
+}
+
+func lockedSkillSource(src string, layout installer.Layout) string {
+	if layout == installer.LayoutFlat && !installer.IsFileSkill(src) {
+		return filepath.Join(src, "SKILL.md")
+	}
+	return src
+}
@@ -0,0 +1,75 @@
+package cli
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func answerPrompts(t *testing.T, answers string) {
+	t.Helper()
+	previous := stdinReader
+	stdinReader = bufio.NewReader(strings.NewReader(answers))
+	t.Cleanup(func() { stdinReader = previous })
+}
+
+func TestFromLockfileConfirmsOverwrite(t *testing.T) {
+	repo := testSkillsRepo(t, "review")
+	home := t.TempDir()
+	target := filepath.Join(home, ".claude", "skills")
+	if err := os.MkdirAll(target, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	lock := filepath.Join(t.TempDir(), "askill.lock")
+	if _, err := runAskill(t, home, "--repo", repo, "--target", "claude-global", "--copy", "--yes", "--write-lockfile", lock); err != nil {
+		t.Fatal(err)
+	}
+	installed := filepath.Join(target, "review", "SKILL.md")
+	edit := func() {
+		t.Helper()
+		if err := os.WriteFile(installed, []byte("local edit"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	content := func() string {
+		t.Helper()
+		data, err := os.ReadFile(installed)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(data)
+	}
+
+	edit()
+	answerPrompts(t, "n\n")
+	out, err := runAskill(t, home, "--from-lockfile", lock)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out, "review exists in Claude Code (global). Overwrite? [y/N]") {
+		t.Errorf("no overwrite prompt in %q", out)
+	}
+	if got := content(); got != "local edit" {
+		t.Fatalf("declined overwrite replaced the install: %q", got)
+	}
+
+	answerPrompts(t, "y\n")
+	if _, err := runAskill(t, home, "--from-lockfile", lock); err != nil {
+		t.Fatal(err)
+	}
+	if got := content(); got == "local edit" {
+		t.Fatal("confirmed overwrite kept the local edit")
+	}
+
+	edit()
+	answerPrompts(t, "")
+	out, err = runAskill(t, home, "--from-lockfile", lock, "--yes")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(out, "Overwrite?") || content() == "local edit" {
+		t.Errorf("--yes did not overwrite without prompting: %q", out)
+	}
+}
@@ -0,0 +1,126 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"agent-skills/internal/installer"
+)
+
+func runMigrateCommand(args []string, cmdName string) (err error) {
+	fs := flag.NewFlagSet(cmdName+" migrate", flag.ContinueOnError)
+	fs.SetOutput(os.Stderr)
+	var repoRoot string
+	var projectPath string
+	var dryRun bool
+	var timeout time.Duration
+	fs.StringVar(&repoRoot, "repo", "", "path to skills repo (defaults to config)")
+	fs.StringVar(&repoRoot, "r", "", "alias for --repo")
+	fs.StringVar(&projectPath, "project", "", "project path for project-local targets")
+	fs.StringVar(&projectPath, "p", "", "alias for --project")
+	fs.BoolVar(&dryRun, "dry-run", false, "report matches without writing meta sidecars")
+	fs.DurationVar(&timeout, "timeout", 0, "abort the migration after this duration (e.g. 30s, 5m)")
+	fs.Usage = func() {
+		out := fs.Output()
+		fmt.Fprintf(out, "Usage: %s migrate [options]\n\n", cmdName)
+		fmt.Fprintln(out, "Write meta sidecars for installs that predate them.")
+		fmt.Fprintln(out)
+		fmt.Fprintln(out, "Options:")
+		tw := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(tw, "  -r, --repo\tPath to skills repo (defaults to config)")
+		fmt.Fprintln(tw, "  -p, --project\tProject path for project-local targets")
+		fmt.Fprintln(tw, "  --dry-run\tReport matches without writing meta sidecars")
+		fmt.Fprintln(tw, "  --timeout\tAbort the migration after this duration (e.g. 30s, 5m)")
+		fmt.Fprintln(tw, "  -h, --help\tShow help")
+		_ = tw.Flush()
+	}
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+
+	ctx, cancel := withTimeout(context.Background(), timeout)
+	defer cancel()
+	defer func() {
+		err = timeoutError(err, timeout)
+	}()
+	root, cleanup, err := resolveRepoRoot(ctx, cloneOptions{}, repoRoot)
+	if err != nil {
+		return err
+	}
+	if cleanup != nil {
+		defer cleanup()
+	}
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	skills, err := installer.DiscoverSkillsWithDescriptionFile(filepath.Join(root, "skills"), descriptionFile(cfg))
+	if err != nil {
+		return fmt.Errorf("discover skills: %w", err)
+	}
+
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return fmt.Errorf("determine home directory: %w", err)
+	}
+	targets, err := discoverTargets(homeDir, projectPath, cfg, true)
+	if err != nil {
+		return err
+	}
+
+	var migrated, unmatched int
+	for _, target := range targets {
+		if _, ok := linkedRoot(target); !target.Exists || ok {
+			continue
+		}
+		entries, err := os.ReadDir(target.Path)
+		if err != nil {
+			return fmt.Errorf("read target %s: %w", target.Path, err)
+		}
+		for _, entry := range entries {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			name := entry.Name()
+			if strings.HasPrefix(name, ".") || installer.IsMetaFile(name) {
+				continue
+			}
+			dest := filepath.Join(target.Path, name)
+			if installer.HasMeta(dest) {
+				continue
+			}
+			meta, ok := installer.MatchInstalled(dest, skills)
+			if !ok {
+				fmt.Printf("Unmatched %s in %s (needs manual attention)\n", name, target.Label)
+				unmatched++
+				continue
+			}
+			migrated++
+			if dryRun {
+				fmt.Printf("Would migrate %s in %s as %s (%s)\n", name, target.Label, meta.Skill, meta.Mode)
+				continue
+			}
+			if err := installer.WriteMeta(dest, meta); err != nil {
+				return fmt.Errorf("write meta for %s: %w", dest, err)
+			}
+			fmt.Printf("Migrated %s in %s as %s (%s)\n", name, target.Label, meta.Skill, meta.Mode)
+		}
+	}
+
+	if dryRun {
+		fmt.Printf("%d to migrate, %d unmatched\n", migrated, unmatched)
+		return nil
+	}
+	fmt.Printf("%d migrated, %d unmatched\n", migrated, unmatched)
+	return nil
+}
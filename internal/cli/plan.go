@@ -0,0 +1,149 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+
+	"agent-skills/internal/installer"
+)
+
+type planItem struct {
+	skill   installer.Skill
+	target  installer.Target
+	dest    string
+	layout  installer.Layout
+	state   installer.InstallState
+	managed bool
+}
+
+func (item planItem) present() bool {
+	return item.state != installer.StateMissing && item.managed
+}
+
+func buildPlan(targets []installer.Target, skills []installer.Skill, mode installer.Mode, opts destOptions) []planItem {
+	items := make([]planItem, 0, len(targets)*len(skills))
+	for _, target := range targets {
+		for _, skill := range skills {
+			layout := skillLayout(skill, opts)
+			dest := skillDestination(target, skill, layout, opts)
+			items = append(items, planItem{
+				skill:   skill,
+				target:  target,
+				dest:    dest,
+				layout:  layout,
+				state:   installer.CheckInstalled(skillSource(skill, layout), dest, mode, layout),
+				managed: installer.IsManagedSkill(dest),
+			})
+		}
+	}
+	return items
+}
+
+func checkDestinationConflicts(items []planItem) error {
+	var conflicts []string
+	seen := make(map[string]planItem)
+	for _, item := range items {
+		key := filepath.Clean(item.dest)
+		prev, ok := seen[key]
+		if !ok {
+			seen[key] = item
+			continue
+		}
+		conflicts = append(conflicts, fmt.Sprintf("%s (%s) and %s (%s) both install to %s in %s", prev.skill.Name, prev.skill.Path, item.skill.Name, item.skill.Path, item.dest, item.target.Label))
+	}
+	if len(conflicts) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%d destination conflict(s), nothing was installed; rename one of the skill directories or leave one out with --exclude:\n  %s", len(conflicts), strings.Join(conflicts, "\n  "))
+}
+
+func reportCheck(items []planItem) error {
+	pending := 0
+	for _, item := range items {
+		if item.state == installer.StateCurrent {
+			continue
+		}
+		pending++
+		fmt.Printf("%s\t%s\t%s\t%s\n", item.state, item.skill.Name, item.target.Type, item.dest)
+	}
+	if pending > 0 {
+		return fmt.Errorf("%d of %d installs are not up to date", pending, len(items))
+	}
+	fmt.Printf("Nothing to do: all %d installs are up to date\n", len(items))
+	return nil
+}
+
+func nothingToDo(items []planItem, onlyMissing bool) (string, bool) {
+	for _, item := range items {
+		if onlyMissing && item.present() || !onlyMissing && item.state == installer.StateCurrent {
+			continue
+		}
+		return "", false
+	}
+	if onlyMissing {
+		return fmt.Sprintf("Nothing to do: all %d installs are already present", len(items)), true
+	}
+	return fmt.Sprintf("Nothing to do: all %d installs are up to date", len(items)), true
+}
+
+func reportDryRun(items []planItem, onlyMissing, validate bool) error {
+	if message, ok := nothingToDo(items, onlyMissing); ok {
+		fmt.Println(message)
+		return nil
+	}
+	problems, validated := 0, 0
+	for _, item := range items {
+		if onlyMissing && item.present() {
+			continue
+		}
+		fmt.Printf("%s\t%s\t%s\t%s\n", item.state, item.skill.Name, item.target.Type, item.dest)
+		if !validate {
+			continue
+		}
+		validated++
+		for _, problem := range destinationProblems(item.dest) {
+			problems++
+			fmt.Printf("  %s\n", warningStyle.Render(problem))
+		}
+	}
+	if problems > 0 {
+		return fmt.Errorf("%d problem(s) would stop the install; nothing was written", problems)
+	}
+	if validate {
+		fmt.Printf("All %d destinations look writable\n", validated)
+	}
+	return nil
+}
+
+func destinationProblems(dest string) []string {
+	var problems []string
+	dir := filepath.Dir(dest)
+	for {
+		info, err := os.Stat(dir)
+		if err == nil {
+			if !info.IsDir() {
+				problems = append(problems, fmt.Sprintf("parent %s is not a directory", dir))
+			} else if !dirWritable(dir) {
+				problems = append(problems, fmt.Sprintf("permission denied: cannot write to %s", dir))
+			}
+			break
+		}
+		if !errors.Is(err, os.ErrNotExist) && !errors.Is(err, syscall.ENOTDIR) {
+			problems = append(problems, fmt.Sprintf("cannot inspect %s: %v", dir, err))
+			break
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			break
+		}
+		dir = parent
+	}
+	if _, err := os.Lstat(dest); err == nil && !installer.IsManagedSkill(dest) {
+		problems = append(problems, fmt.Sprintf("unmanaged conflict: %s exists and was not installed by askill", dest))
+	}
+	return problems
+}
@@ -0,0 +1,202 @@
+package cli
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"agent-skills/internal/installer"
+)
+
+func captureStdout(t *testing.T, fn func()) string {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	previous := os.Stdout
+	os.Stdout = w
+	done := make(chan string)
+	go func() {
+		data, _ := io.ReadAll(r)
+		done <- string(data)
+	}()
+	defer func() { os.Stdout = previous }()
+	fn()
+	w.Close()
+	return <-done
+}
+
+func testSkillsRepo(t *testing.T, names ...string) string {
+	t.Helper()
+	repo := t.TempDir()
+	for _, name := range names {
+		writeTestFile(t, filepath.Join(repo, "skills", name, "SKILL.md"), "---\nname: "+name+"\n---\n")
+	}
+	return repo
+}
+
+func writeTestFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func runAskill(t *testing.T, home string, args ...string) (string, error) {
+	t.Helper()
+	t.Setenv("HOME", home)
+	if configPathOverride == "" {
+		useConfigFile(t, "")
+	}
+	var err error
+	out := captureStdout(t, func() {
+		err = Run(append([]string{"askill"}, args...), Options{})
+	})
+	return out, err
+}
+
+func TestInstallNothingToDo(t *testing.T) {
+	repo := testSkillsRepo(t, "one", "two")
+	home := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(home, ".claude", "skills"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	args := []string{"--repo", repo, "--target", "claude-global", "--symlink", "--yes"}
+
+	if out, err := runAskill(t, home, args...); err != nil || strings.Contains(out, "Nothing to do") {
+		t.Fatalf("first install: %v\n%s", err, out)
+	}
+	for _, extra := range [][]string{nil, {"--only-missing"}, {"--dry-run"}, {"--check"}} {
+		out, err := runAskill(t, home, append(args, extra...)...)
+		if err != nil {
+			t.Fatalf("%v: %v", extra, err)
+		}
+		if !strings.HasPrefix(out, "Nothing to do: all 2 installs are ") {
+			t.Errorf("%v: output = %q, want a nothing-to-do message", extra, out)
+		}
+	}
+}
+
+func TestNothingToDoOnlyMissing(t *testing.T) {
+	var present, missing, unmanaged planItem
+	present.state = installer.StateDrifted
+	present.managed = true
+	missing.state = installer.StateMissing
+	unmanaged.state = installer.StateCurrent
+	if _, ok := nothingToDo([]planItem{present}, false); ok {
+		t.Error("a drifted install was reported as nothing to do")
+	}
+	if message, ok := nothingToDo([]planItem{present}, true); !ok || !strings.Contains(message, "already present") {
+		t.Errorf("--only-missing with a present install = %q, %v", message, ok)
+	}
+	if _, ok := nothingToDo([]planItem{present, missing}, true); ok {
+		t.Error("a missing install was reported as nothing to do")
+	}
+	if _, ok := nothingToDo([]planItem{unmanaged}, true); ok {
+		t.Error("--only-missing counted an unmanaged entry as present")
+	}
+}
+
+func TestOnlyMissingIgnoresUnmanagedEntries(t *testing.T) {
+	repo := testSkillsRepo(t, "managed", "foreign")
+	home := t.TempDir()
+	skillsDir := filepath.Join(home, ".claude", "skills")
+	if err := os.MkdirAll(skillsDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	args := []string{"--repo", repo, "--target", "claude-global", "--copy", "--yes", "--only-missing"}
+	if _, err := runAskill(t, home, append(args, "--exclude", "foreign")...); err != nil {
+		t.Fatal(err)
+	}
+	foreign := filepath.Join(skillsDir, "foreign")
+	writeTestFile(t, filepath.Join(foreign, "SKILL.md"), "hand written\n")
+
+	out, err := runAskill(t, home, append(args, "--dry-run")...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(out, "Nothing to do") || !strings.Contains(out, foreign) || strings.Contains(out, filepath.Join(skillsDir, "managed")) {
+		t.Fatalf("dry run should list only the unmanaged entry:\n%s", out)
+	}
+	if _, err := runAskill(t, home, args...); err != nil {
+		t.Fatal(err)
+	}
+	if !installer.IsManagedSkill(foreign) {
+		t.Fatal("--only-missing left the unmanaged entry in place")
+	}
+}
+
+func TestOnlyMissingCountsDeclinedSkills(t *testing.T) {
+	repo := testSkillsRepo(t, "kept", "fresh")
+	home := t.TempDir()
+	skillsDir := filepath.Join(home, ".claude", "skills")
+	writeTestFile(t, filepath.Join(skillsDir, "kept", "SKILL.md"), "hand written\n")
+	answerPrompts(t, "n\n")
+
+	out, err := runAskill(t, home, "--repo", repo, "--target", "claude-global", "--copy", "--skills", "kept,fresh", "--only-missing")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out, ": 0 already present, 1 installed") {
+		t.Fatalf("output = %q, want the declined skill left out of the count", out)
+	}
+	if installer.IsManagedSkill(filepath.Join(skillsDir, "kept")) {
+		t.Fatal("declined skill was installed")
+	}
+}
+
+func TestValidateTargetsWritesNothing(t *testing.T) {
+	repo := testSkillsRepo(t, "installed", "pending")
+	home := t.TempDir()
+	skillsDir := filepath.Join(home, ".claude", "skills")
+	if err := os.MkdirAll(skillsDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	args := []string{"--repo", repo, "--target", "claude-global", "--copy", "--yes"}
+	if _, err := runAskill(t, home, append(args, "--skills", "installed")...); err != nil {
+		t.Fatal(err)
+	}
+	before, err := os.Stat(skillsDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := runAskill(t, home, append(args, "--only-missing", "--dry-run", "--validate-targets")...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out, "All 1 destinations look writable") {
+		t.Fatalf("output = %q, want only the pending destination counted", out)
+	}
+	after, err := os.Stat(skillsDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !after.ModTime().Equal(before.ModTime()) {
+		t.Fatalf("dry run wrote to %s", skillsDir)
+	}
+}
+
+func TestDestinationProblems(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "file")
+	writeTestFile(t, file, "")
+	unmanaged := filepath.Join(dir, "unmanaged")
+	writeTestFile(t, filepath.Join(unmanaged, "SKILL.md"), "")
+
+	if problems := destinationProblems(filepath.Join(dir, "skill")); len(problems) != 0 {
+		t.Errorf("free destination: %v", problems)
+	}
+	if problems := destinationProblems(filepath.Join(file, "skill")); len(problems) != 1 || !strings.Contains(problems[0], "not a directory") {
+		t.Errorf("file parent: %v", problems)
+	}
+	if problems := destinationProblems(unmanaged); len(problems) != 1 || !strings.Contains(problems[0], "unmanaged conflict") {
+		t.Errorf("unmanaged entry: %v", problems)
+	}
+}
@@ -0,0 +1,29 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/mattn/go-isatty"
+)
+
+const progressThreshold = 16 * 1024 * 1024
+
+func isTerminal(f *os.File) bool {
+	return isatty.IsTerminal(f.Fd()) || isatty.IsCygwinTerminal(f.Fd())
+}
+
+func copyProgress(out io.Writer) func(path string, copied, total int64) {
+	return func(path string, copied, total int64) {
+		if total < progressThreshold {
+			return
+		}
+		const mb = 1024 * 1024
+		fmt.Fprintf(out, "\r  copying %s: %.1f / %.1f MB", filepath.Base(path), float64(copied)/mb, float64(total)/mb)
+		if copied >= total {
+			fmt.Fprintln(out)
+		}
+	}
+}
@@ -0,0 +1,24 @@
+package cli
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestCopyProgressOnlyReportsLargeFiles(t *testing.T) {
+	var out bytes.Buffer
+	progress := copyProgress(&out)
+	progress("/repo/skills/small/SKILL.md", 10, 10)
+	if out.Len() != 0 {
+		t.Fatalf("small file printed progress: %q", out.String())
+	}
+
+	total := int64(progressThreshold * 2)
+	progress("/repo/skills/big/model.bin", total/2, total)
+	progress("/repo/skills/big/model.bin", total, total)
+	got := out.String()
+	if !strings.Contains(got, "model.bin: 16.0 / 32.0 MB") || !strings.HasSuffix(got, "model.bin: 32.0 / 32.0 MB\n") {
+		t.Fatalf("progress output = %q", got)
+	}
+}
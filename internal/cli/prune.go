@@ -0,0 +1,170 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+
+	"agent-skills/internal/installer"
+)
+
+type orphanedEntry struct {
+	managedEntry
+	reason string
+}
+
+func runPruneCommand(args []string, cmdName string) error {
+	fs := flag.NewFlagSet(cmdName+" prune", flag.ContinueOnError)
+	fs.SetOutput(os.Stderr)
+	var repoRoot string
+	var projectPath string
+	var targetList string
+	var dryRun bool
+	var yes bool
+	fs.StringVar(&repoRoot, "repo", "", "skills repo to compare installs against (defaults to config)")
+	fs.StringVar(&repoRoot, "r", "", "alias for --repo")
+	fs.StringVar(&projectPath, "project", "", "also prune project-local targets under this path")
+	fs.StringVar(&projectPath, "p", "", "alias for --project")
+	fs.StringVar(&targetList, "target", "", "comma-separated target types to prune (defaults to all)")
+	fs.BoolVar(&dryRun, "dry-run", false, "report what would be pruned without removing it")
+	fs.BoolVar(&yes, "yes", false, "skip the confirmation prompt")
+	fs.BoolVar(&yes, "y", false, "alias for --yes")
+	fs.Usage = func() {
+		out := fs.Output()
+		fmt.Fprintf(out, "Usage: %s prune [options]\n\n", cmdName)
+		fmt.Fprintln(out, "Remove askill-managed skills whose source skill is gone from the repo, and symlinks into deleted paths.")
+		fmt.Fprintln(out)
+		fmt.Fprintln(out, "Options:")
+		tw := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(tw, "  -r, --repo\tSkills repo to compare installs against (defaults to config)")
+		fmt.Fprintln(tw, "  -p, --project\tAlso prune project-local targets under this path")
+		fmt.Fprintf(tw, "  --target\tComma-separated target types (%s)\n", joinTargetTypes())
+		fmt.Fprintln(tw, "  --dry-run\tReport what would be pruned without removing it")
+		fmt.Fprintln(tw, "  -y, --yes\tSkip the confirmation prompt")
+		fmt.Fprintln(tw, "  -h, --help\tShow help")
+		_ = tw.Flush()
+	}
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+	if fs.NArg() > 0 {
+		fs.Usage()
+		return errors.New("prune takes no arguments")
+	}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	root, cleanup, err := resolveRepoRoot(context.Background(), cloneOptions{}, repoRoot)
+	if err != nil {
+		return err
+	}
+	if cleanup != nil {
+		defer cleanup()
+	}
+	skillsRoot := filepath.Join(root, "skills")
+	skills, err := installer.DiscoverSkillsWithDescriptionFile(skillsRoot, descriptionFile(cfg))
+	if err != nil {
+		return fmt.Errorf("discover skills: %w", err)
+	}
+	known := make(map[string]bool, len(skills))
+	for _, skill := range skills {
+		known[skill.Name] = true
+	}
+
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return fmt.Errorf("determine home directory: %w", err)
+	}
+	targets, err := discoverTargets(homeDir, projectPath, cfg, true)
+	if err != nil {
+		return err
+	}
+	if targetList != "" {
+		targets, err = filterTargetTypes(targets, splitList(targetList))
+		if err != nil {
+			return err
+		}
+	}
+	entries, err := managedEntries(targets)
+	if err != nil {
+		return err
+	}
+	var orphans []orphanedEntry
+	for _, entry := range entries {
+		if reason, ok := orphanReason(entry, skillsRoot, known); ok {
+			orphans = append(orphans, orphanedEntry{managedEntry: entry, reason: reason})
+		}
+	}
+	if len(orphans) == 0 {
+		fmt.Println("No orphaned skills to prune")
+		return nil
+	}
+
+	if !dryRun && !yes {
+		for _, orphan := range orphans {
+			fmt.Printf("  %s in %s (%s)\n", orphan.name, orphan.target.Label, orphan.reason)
+		}
+		if !confirm(stdinReader, fmt.Sprintf("Prune %d orphaned skill(s)? [y/N]: ", len(orphans))) {
+			return errors.New("prune cancelled")
+		}
+	}
+	for _, orphan := range orphans {
+		if dryRun {
+			fmt.Printf("Would prune %s from %s (%s)\n", orphan.name, orphan.target.Label, orphan.reason)
+			continue
+		}
+		if err := installer.RemoveSkill(orphan.dest); err != nil {
+			return fmt.Errorf("remove %s: %w", orphan.dest, err)
+		}
+		fmt.Printf("Pruned %s from %s (%s)\n", orphan.name, orphan.target.Label, orphan.reason)
+	}
+	return nil
+}
+
+func orphanReason(entry managedEntry, skillsRoot string, known map[string]bool) (string, bool) {
+	if entry.name == installer.AllSkills {
+		return "", false
+	}
+	if info, err := os.Lstat(entry.dest); err == nil && info.Mode()&os.ModeSymlink != 0 {
+		if _, err := os.Stat(entry.dest); err != nil {
+			link, _ := os.Readlink(entry.dest)
+			return fmt.Sprintf("links to deleted path %s", link), true
+		}
+	}
+	meta, err := installer.ReadMeta(entry.dest)
+	if err != nil || !pathWithin(meta.Source, skillsRoot) {
+		return "", false
+	}
+	if !known[entry.name] {
+		return "no longer in the repo", true
+	}
+	return "", false
+}
+
+func pathWithin(path, root string) bool {
+	if path == "" {
+		return false
+	}
+	path = absDir(path)
+	roots := []string{absDir(root)}
+	if resolved, err := filepath.EvalSymlinks(root); err == nil {
+		roots = append(roots, resolved)
+	}
+	for _, candidate := range roots {
+		rel, err := filepath.Rel(candidate, path)
+		if err == nil && rel != "." && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return true
+		}
+	}
+	return false
+}
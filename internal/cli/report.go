@@ -0,0 +1,178 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"agent-skills/internal/installer"
+)
+
+type reporter struct {
+	summaryOnly bool
+	quiet       bool
+	warnings    []string
+	results     []installResult
+}
+
+func (r *reporter) warnf(format string, args ...any) {
+	msg := fmt.Sprintf(format, args...)
+	r.warnings = append(r.warnings, msg)
+	fmt.Fprintf(os.Stderr, "warning: %s\n", msg)
+}
+
+func (r *reporter) strictError() error {
+	if len(r.warnings) == 0 {
+		return nil
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "strict mode: %d warning(s) emitted:", len(r.warnings))
+	for _, warning := range r.warnings {
+		b.WriteString("\n  - ")
+		b.WriteString(warning)
+	}
+	return errors.New(b.String())
+}
+
+type installAction string
+
+const (
+	actionInstalled   installAction = "installed"
+	actionSkipped     installAction = "skipped"
+	actionOverwritten installAction = "overwritten"
+	actionFailed      installAction = "failed"
+)
+
+type installResult struct {
+	Skill  string               `json:"skill"`
+	Target installer.TargetType `json:"target"`
+	Path   string               `json:"path"`
+	Action installAction        `json:"action"`
+	Mode   installer.Mode       `json:"mode"`
+	Error  string               `json:"error,omitempty"`
+
+	label    string
+	upToDate bool
+}
+
+type installSummary struct {
+	Installed   int `json:"installed"`
+	Skipped     int `json:"skipped"`
+	Overwritten int `json:"overwritten"`
+	Failed      int `json:"failed"`
+}
+
+func (r *reporter) terse() bool {
+	return r.summaryOnly || r.quiet
+}
+
+func (r *reporter) record(result installResult) {
+	r.results = append(r.results, result)
+	if r.terse() {
+		return
+	}
+	switch result.Action {
+	case actionInstalled, actionOverwritten:
+		fmt.Printf("Installed %s to %s (%s)\n", result.Skill, result.label, result.Mode)
+	case actionSkipped:
+		if result.upToDate {
+			fmt.Printf("%s is already up to date in %s\n", result.Skill, result.label)
+			return
+		}
+		fmt.Printf("Skipping %s for %s\n", result.Skill, result.label)
+	}
+}
+
+func (r *reporter) fail(result installResult, err error) error {
+	result.Action = actionFailed
+	result.Error = err.Error()
+	r.record(result)
+	if r.terse() {
+		r.printSummary()
+	}
+	return err
+}
+
+func (r *reporter) summary() installSummary {
+	var s installSummary
+	for _, result := range r.results {
+		switch result.Action {
+		case actionInstalled:
+			s.Installed++
+		case actionSkipped:
+			s.Skipped++
+		case actionOverwritten:
+			s.Overwritten++
+		case actionFailed:
+			s.Failed++
+		}
+	}
+	return s
+}
+
+func (r *reporter) printSummary() {
+	s := r.summary()
+	if r.quiet {
+		r.printQuietSummary(s)
+		return
+	}
+	fmt.Printf("Summary: %d installed, %d skipped, %d overwritten, %d failed\n", s.Installed, s.Skipped, s.Overwritten, s.Failed)
+}
+
+func (r *reporter) printQuietSummary(s installSummary) {
+	targets := make(map[installer.TargetType]bool)
+	for _, result := range r.results {
+		if result.Action == actionInstalled || result.Action == actionOverwritten {
+			targets[result.Target] = true
+		}
+	}
+	line := fmt.Sprintf("Installed %s across %s", countNoun(s.Installed+s.Overwritten, "skill"), countNoun(len(targets), "target"))
+	var extra []string
+	if s.Skipped > 0 {
+		extra = append(extra, fmt.Sprintf("%d skipped", s.Skipped))
+	}
+	if s.Failed > 0 {
+		extra = append(extra, fmt.Sprintf("%d failed", s.Failed))
+	}
+	if len(extra) > 0 {
+		line += " (" + strings.Join(extra, ", ") + ")"
+	}
+	fmt.Println(line)
+}
+
+func countNoun(n int, noun string) string {
+	if n == 1 {
+		return fmt.Sprintf("1 %s", noun)
+	}
+	return fmt.Sprintf("%d %ss", n, noun)
+}
+
+type summaryFile struct {
+	Summary installSummary  `json:"summary"`
+	Results []installResult `json:"results"`
+	Error   string          `json:"error,omitempty"`
+}
+
+func (r *reporter) writeSummaryFile(path string, runErr error) error {
+	file := summaryFile{Summary: r.summary(), Results: r.results}
+	if file.Results == nil {
+		file.Results = []installResult{}
+	}
+	if runErr != nil {
+		file.Error = runErr.Error()
+	}
+	data, err := json.MarshalIndent(file, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("create summary file dir: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("write summary file: %w", err)
+	}
+	return nil
+}
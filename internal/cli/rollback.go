@@ -0,0 +1,103 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"agent-skills/internal/installer"
+)
+
+type installJournal struct {
+	entries []journalEntry
+}
+
+type journalEntry struct {
+	dest   string
+	backup string
+	done   bool
+}
+
+func newInstallJournal() *installJournal {
+	return &installJournal{}
+}
+
+func (j *installJournal) prepare(ctx context.Context, dest string, keep bool) error {
+	entry := journalEntry{dest: dest}
+	if _, err := os.Lstat(dest); err == nil {
+		backup, err := os.MkdirTemp(filepath.Dir(dest), "."+filepath.Base(dest)+".rollback-*")
+		if err != nil {
+			return fmt.Errorf("back up %s: %w", dest, err)
+		}
+		saved := filepath.Join(backup, filepath.Base(dest))
+		if keep {
+			err = installer.InstallSkillContext(ctx, dest, saved, installer.ModeCopy)
+		} else {
+			err = os.Rename(dest, saved)
+		}
+		if err == nil {
+			err = os.Rename(installer.MetaPath(dest), installer.MetaPath(saved))
+			if errors.Is(err, os.ErrNotExist) {
+				err = nil
+			}
+		}
+		if err != nil {
+			if !keep {
+				_ = os.Rename(saved, dest)
+			}
+			_ = os.RemoveAll(backup)
+			return fmt.Errorf("back up %s: %w", dest, err)
+		}
+		entry.backup = backup
+	}
+	j.entries = append(j.entries, entry)
+	return nil
+}
+
+func (j *installJournal) complete() {
+	if len(j.entries) == 0 {
+		return
+	}
+	j.entries[len(j.entries)-1].done = true
+}
+
+func (j *installJournal) finish(ctx context.Context, runErr error, report *reporter) {
+	restored := 0
+	for i := len(j.entries) - 1; i >= 0; i-- {
+		entry := j.entries[i]
+		if runErr != nil && (ctx.Err() != nil || !entry.done) {
+			if err := entry.restore(); err != nil {
+				report.warnf("roll back %s: %v", entry.dest, err)
+				continue
+			}
+			restored++
+			continue
+		}
+		if entry.backup != "" {
+			_ = os.RemoveAll(entry.backup)
+		}
+	}
+	j.entries = nil
+	if ctx.Err() != nil && restored > 0 {
+		report.warnf("rolled back %d install(s) after %v", restored, ctx.Err())
+	}
+}
+
+func (e journalEntry) restore() error {
+	if err := installer.RemoveSkill(e.dest); err != nil {
+		return err
+	}
+	if e.backup == "" {
+		return nil
+	}
+	saved := filepath.Join(e.backup, filepath.Base(e.dest))
+	if err := os.Rename(saved, e.dest); err != nil {
+		return err
+	}
+	if err := os.Rename(installer.MetaPath(saved), installer.MetaPath(e.dest)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return os.RemoveAll(e.backup)
+}
@@ -0,0 +1,150 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"agent-skills/internal/installer"
+)
+
+func installForJournal(t *testing.T, dest, content string) {
+	t.Helper()
+	if err := os.RemoveAll(dest); err != nil {
+		t.Fatal(err)
+	}
+	writeTestFile(t, filepath.Join(dest, "SKILL.md"), content)
+	if err := installer.WriteMeta(dest, installer.Meta{Skill: content}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func assertInstall(t *testing.T, dest, content string) {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join(dest, "SKILL.md"))
+	if err != nil || string(data) != content {
+		t.Errorf("%s holds %q (%v), want %q", dest, data, err, content)
+	}
+	if meta, err := installer.ReadMeta(dest); err != nil || meta.Skill != content {
+		t.Errorf("%s meta = %+v (%v), want skill %q", dest, meta, err, content)
+	}
+}
+
+func assertNoBackups(t *testing.T, dir string) {
+	t.Helper()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, entry := range entries {
+		if strings.Contains(entry.Name(), ".rollback-") {
+			t.Errorf("left behind %s", entry.Name())
+		}
+	}
+}
+
+func TestInstallJournalRollsBackOnTimeout(t *testing.T) {
+	target := t.TempDir()
+	existing := filepath.Join(target, "existing")
+	added := filepath.Join(target, "added")
+	merged := filepath.Join(target, "merged")
+	installForJournal(t, existing, "old")
+	installForJournal(t, merged, "old merge")
+	ctx, cancel := context.WithTimeout(context.Background(), time.Hour)
+	journal := newInstallJournal()
+
+	for _, step := range []struct {
+		dest string
+		keep bool
+	}{{existing, false}, {added, false}, {merged, true}} {
+		if err := journal.prepare(ctx, step.dest, step.keep); err != nil {
+			t.Fatal(err)
+		}
+		if !step.keep {
+			if _, err := os.Lstat(step.dest); err == nil {
+				t.Fatalf("%s was not moved aside", step.dest)
+			}
+		}
+		installForJournal(t, step.dest, "new")
+		journal.complete()
+	}
+	cancel()
+	journal.finish(ctx, ctx.Err(), &reporter{})
+
+	assertInstall(t, existing, "old")
+	assertInstall(t, merged, "old merge")
+	if _, err := os.Lstat(added); err == nil {
+		t.Error("install added by the timed-out run was kept")
+	}
+	if installer.HasMeta(added) {
+		t.Error("meta added by the timed-out run was kept")
+	}
+	assertNoBackups(t, target)
+}
+
+func TestInstallJournalRestoresOnlyFailedInstall(t *testing.T) {
+	target := t.TempDir()
+	first := filepath.Join(target, "first")
+	second := filepath.Join(target, "second")
+	installForJournal(t, first, "old first")
+	installForJournal(t, second, "old second")
+	ctx := context.Background()
+	journal := newInstallJournal()
+
+	if err := journal.prepare(ctx, first, false); err != nil {
+		t.Fatal(err)
+	}
+	installForJournal(t, first, "new first")
+	journal.complete()
+	if err := journal.prepare(ctx, second, false); err != nil {
+		t.Fatal(err)
+	}
+	writeTestFile(t, filepath.Join(second, "partial"), "")
+	journal.finish(ctx, errors.New("copy failed"), &reporter{})
+
+	assertInstall(t, first, "new first")
+	assertInstall(t, second, "old second")
+	if _, err := os.Stat(filepath.Join(second, "partial")); err == nil {
+		t.Error("partial install survived the restore")
+	}
+	assertNoBackups(t, target)
+}
+
+func fakeSlowGit(t *testing.T) {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("fake git is a shell script")
+	}
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "git"), []byte("#!/bin/sh\nexec sleep 30\n"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+}
+
+func TestTimeoutAbortsSlowClone(t *testing.T) {
+	fakeSlowGit(t)
+	home := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(home, ".claude", "skills"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	tests := [][]string{
+		{"--repo", "https://git.example.com/team/skills.git", "--no-cache", "--target", "claude-global", "--yes", "--timeout", "200ms"},
+		{"migrate", "--repo", "https://git.example.com/team/skills.git", "--timeout", "200ms"},
+	}
+	for _, args := range tests {
+		start := time.Now()
+		_, err := runAskill(t, home, args...)
+		if err == nil || !strings.Contains(err.Error(), "timed out after 200ms") {
+			t.Errorf("%v: error = %v, want a timeout", args, err)
+		}
+		if elapsed := time.Since(start); elapsed > 10*time.Second {
+			t.Errorf("%v: took %s to time out", args, elapsed)
+		}
+	}
+}
@@ -2,17 +2,21 @@ package cli
 
 import (
 	"bufio"
+	"context"
 	"encoding/json"
 	"errors"
 	"flag"
 	"fmt"
 	"os"
 	"os/exec"
+	"os/user"
 	"path/filepath"
 	"sort"
 	"strconv"
 	"strings"
 	"text/tabwriter"
+	"time"
+	"unicode"
 
 	"agent-skills/internal/installer"
 
@@ -23,14 +27,49 @@ type Options struct {
 	CommandName string
 }
 
-func Run(args []string, opts Options) error {
+func Run(args []string, opts Options) (err error) {
 	cmdName := opts.CommandName
 	if cmdName == "" {
 		cmdName = filepath.Base(args[0])
 	}
+	stopInterrupts := handleInterrupts()
+	defer stopInterrupts()
+	args = enableVerbose(args)
+	if args, err = extractConfigPath(args); err != nil {
+		return err
+	}
 
-	if len(args) > 1 && args[1] == "config" {
-		return runConfigCommand(args[2:], cmdName)
+	if len(args) > 1 {
+		switch args[1] {
+		case "config":
+			return runConfigCommand(args[2:], cmdName)
+		case "migrate":
+			return runMigrateCommand(args[2:], cmdName)
+		case "uninstall":
+			return runUninstallCommand(args[2:], cmdName)
+		case "installed":
+			return runInstalledCommand(args[2:], cmdName)
+		case "list":
+			return runListCommand(args[2:], cmdName)
+		case "validate":
+			return runValidateCommand(args[2:], cmdName)
+		case "doctor":
+			return runDoctorCommand(args[2:], cmdName)
+		case "search":
+			return runSearchCommand(args[2:], cmdName)
+		case "info":
+			return runInfoCommand(args[2:], cmdName)
+		case "which":
+			return runWhichCommand(args[2:], cmdName)
+		case "status":
+			return runStatusCommand(args[2:], cmdName)
+		case "update":
+			return runUpdateCommand(args[2:], cmdName)
+		case "prune":
+			return runPruneCommand(args[2:], cmdName)
+		case "bench":
+			return runBenchCommand(args[2:], cmdName)
+		}
 	}
 
 	fs := flag.NewFlagSet(cmdName, flag.ContinueOnError)
@@ -39,35 +78,198 @@ func Run(args []string, opts Options) error {
 	var projectPath string
 	var copyMode bool
 	var symlinkMode bool
+	var hardlinkMode bool
 	var showVersion bool
 	var fromConfig bool
-
-	fs.StringVar(&repoRoot, "repo", "", "path to skills repo (defaults to current directory)")
+	var onlyMissing bool
+	var flat bool
+	var timeout time.Duration
+	var sshKey string
+	var defaultSelection string
+	var pageSize int
+	var latestRelease bool
+	var printConfigPath bool
+	var autodetectSkillsDir bool
+	var check bool
+	var strict bool
+	var gitignore bool
+	var summaryOnly bool
+	var quiet bool
+	var homeUser string
+	var includeDisabled bool
+	var repoRootMarker string
+	var maxCloneSize string
+	var targetPriority string
+	var describe bool
+	var installOrder string
+	var noOverwritePrompt bool
+	var skillNames string
+	var singleFileSkills bool
+	var sanitizeNames bool
+	var maxSkills int
+	var noDedupeTargets bool
+	var summaryPath string
+	var match string
+	var tags stringsFlag
+	var excludes stringsFlag
+	var targetTypes stringsFlag
+	var category string
+	var fromLockfile string
+	var writeLockfilePath string
+	var linkAll bool
+	var yes bool
+	var repoTokenFile string
+	var repoName string
+	var noUpdateCheck bool
+	var refresh bool
+	var noCache bool
+	var merge bool
+	var stamp bool
+	var dryRun bool
+	var validateTargets bool
+
+	fs.StringVar(&repoRoot, "repo", "", "path to skills repo, a .tar.gz/.zip of one, or a git URL (defaults to current directory)")
 	fs.StringVar(&repoRoot, "r", "", "alias for --repo")
+	fs.StringVar(&repoName, "repo-name", "", "install from the skills repo with this name in the config repos list")
 	fs.StringVar(&projectPath, "project", "", "project path for project-local installs")
 	fs.StringVar(&projectPath, "p", "", "alias for --project")
 	fs.BoolVar(&copyMode, "copy", false, "copy files instead of symlink")
 	fs.BoolVar(&copyMode, "c", false, "alias for --copy")
 	fs.BoolVar(&symlinkMode, "symlink", false, "force symlink mode")
 	fs.BoolVar(&symlinkMode, "s", false, "alias for --symlink")
+	fs.BoolVar(&hardlinkMode, "hardlink", false, "hard-link files instead of copying or symlinking")
 	fs.BoolVar(&showVersion, "version", false, "print version and exit")
 	fs.BoolVar(&showVersion, "v", false, "alias for --version")
+	fs.BoolVar(&printConfigPath, "print-config-path", false, "print the config file path and exit")
 	fs.BoolVar(&fromConfig, "from-config", false, "install all skills using config defaults")
 	fs.BoolVar(&fromConfig, "f", false, "alias for --from-config")
+	fs.BoolVar(&onlyMissing, "only-missing", false, "install only skills not already present in each target")
+	fs.BoolVar(&flat, "flat", false, "install single-file skills as <name>.md instead of a directory")
+	fs.DurationVar(&timeout, "timeout", 0, "abort the whole run after this duration (e.g. 30s, 5m)")
+	fs.StringVar(&sshKey, "ssh-key", "", "SSH private key to use when cloning the skills repo")
+	fs.StringVar(&defaultSelection, "default-selection", "", "initial TUI selection: all or none")
+	fs.IntVar(&pageSize, "page-size", 0, "items per page in numeric selection prompts (0 shows all)")
+	fs.BoolVar(&latestRelease, "release", false, "install a GitHub repo from its latest release tarball instead of cloning")
+	fs.BoolVar(&autodetectSkillsDir, "autodetect-skills-dir", false, "find the skills directory when the repo has no skills/ folder")
+	fs.BoolVar(&check, "check", false, "exit non-zero if any skill is missing or out of date in the targets")
+	fs.BoolVar(&strict, "strict", false, "exit non-zero if any warning was emitted")
+	fs.BoolVar(&gitignore, "gitignore", false, "add project installs to the project's .gitignore")
+	fs.BoolVar(&summaryOnly, "summary-only", false, "print only the end-of-run summary instead of per-skill lines")
+	fs.BoolVar(&quiet, "quiet", false, "hide per-skill lines and print one summary line; errors and warnings still print")
+	fs.BoolVar(&quiet, "q", false, "alias for --quiet")
+	fs.StringVar(&homeUser, "home-user", "", "install into global targets under this user's home directory")
+	fs.BoolVar(&includeDisabled, "include-disabled", false, "include skills marked enabled: false or disabled: true")
+	fs.StringVar(&repoRootMarker, "repo-root-marker", "", "bundled skills repo location relative to the executable")
+	fs.StringVar(&maxCloneSize, "max-clone-size", "", "ask before cloning GitHub repos larger than this (e.g. 200MB)")
+	fs.StringVar(&targetPriority, "target-priority", "", "comma-separated target types to install to first")
+	fs.BoolVar(&describe, "describe", false, "print why each skill is included or excluded and exit")
+	fs.StringVar(&installOrder, "install-order", installOrderAlpha, "order skills are installed in: alpha, priority, or deps")
+	fs.BoolVar(&noOverwritePrompt, "no-overwrite-prompt", false, "fail without installing if any selected skill already exists")
+	fs.StringVar(&skillNames, "skills", "", "comma-separated skill names or aliases to install without prompting")
+	fs.BoolVar(&singleFileSkills, "single-file-skills", false, "discover *.md files with frontmatter in the skills root as skills")
+	fs.BoolVar(&sanitizeNames, "sanitize-names", false, "replace spaces and drop symbols such as emoji in installed names")
+	fs.IntVar(&maxSkills, "max-skills", 0, "refuse to install more than this many skills (0 means unlimited)")
+	fs.BoolVar(&noDedupeTargets, "no-dedupe-targets", false, "keep targets that resolve to the same directory through symlinks")
+	fs.StringVar(&summaryPath, "post-install-summary-file", "", "also write the run results as JSON to this file")
+	fs.StringVar(&summaryPath, "report", "", "alias for --post-install-summary-file")
+	fs.StringVar(&match, "match", "", "only offer skills whose name, description, or tags contain this text (case-insensitive)")
+	fs.Var(&tags, "tag", "only offer skills tagged with this tag (repeatable; all must match)")
+	fs.StringVar(&category, "category", "", "only offer skills in this frontmatter category")
+	fs.StringVar(&fromLockfile, "from-lockfile", "", "install exactly the skills, targets, and modes recorded in this lockfile")
+	fs.StringVar(&writeLockfilePath, "write-lockfile", "", "record the installed skills and their pinned sources in this lockfile")
+	fs.BoolVar(&linkAll, "link-all", false, "symlink each target skills directory to the repo skills directory instead of installing per skill")
+	fs.BoolVar(&yes, "yes", false, "overwrite existing skills and select all targets without prompting")
+	fs.BoolVar(&yes, "y", false, "alias for --yes")
+	fs.StringVar(&repoTokenFile, "repo-token-file", "", "read an access token for cloning private HTTPS repos from this file")
+	fs.BoolVar(&refresh, "refresh", false, "fetch cached remote repos before discovery, ignoring refresh-cooldown")
+	fs.BoolVar(&noCache, "no-cache", false, "clone remote repos into a temp dir instead of the clone cache")
+	fs.BoolVar(&merge, "merge", false, "copy installs overlay existing skill directories instead of replacing them")
+	fs.BoolVar(&stamp, "stamp", false, "write the source repo URL and commit to .askill-source in each copied skill")
+	fs.BoolVar(&dryRun, "dry-run", false, "print what would be installed where and exit without writing")
+	fs.BoolVar(&validateTargets, "validate-targets", false, "with --dry-run, check each destination for problems the real run would hit")
+	fs.Var(&excludes, "exclude", "leave out the skill with this name or alias (repeatable)")
+	fs.Var(&targetTypes, "target", "install only to this target type, without prompting (repeatable)")
+	fs.Bool("verbose", false, "log repo, config, skill, and target resolution and each install step to stderr")
+	fs.Bool("V", false, "alias for --verbose")
+	fs.String("config", "", "read and write the config file at this path instead of the default (env: ASKILL_CONFIG)")
+	fs.BoolVar(&noUpdateCheck, "no-update-check", false, "skip the check for a newer askill release")
 
 	fs.Usage = func() {
 		out := fs.Output()
-		fmt.Fprintf(out, "Usage: %s [options]\n", cmdName)
-		fmt.Fprintf(out, "       %s config [--init] [-e|--edit]\n\n", cmdName)
+		fmt.Fprintf(out, "Usage: %s [options] [SKILL]\n", cmdName)
+		fmt.Fprintf(out, "       %s config [--init] [-e|--edit] [get KEY | set KEY VALUE]\n", cmdName)
+		fmt.Fprintf(out, "       %s migrate [--dry-run] [--timeout DURATION]\n", cmdName)
+		fmt.Fprintf(out, "       %s uninstall [--dry-run] [-y] [--all | skill...]\n", cmdName)
+		fmt.Fprintf(out, "       %s installed [-p PATH] [--json]\n", cmdName)
+		fmt.Fprintf(out, "       %s list [-r REPO] [-p PATH] [--json]\n", cmdName)
+		fmt.Fprintf(out, "       %s validate [--strict] [PATH]\n", cmdName)
+		fmt.Fprintf(out, "       %s doctor [-p PATH]\n", cmdName)
+		fmt.Fprintf(out, "       %s which [-r REPO] [-p PATH] [--json] SKILL\n", cmdName)
+		fmt.Fprintf(out, "       %s info [-r REPO] [-p PATH] [--json] SKILL\n", cmdName)
+		fmt.Fprintf(out, "       %s search [-r REPO] [--limit N] [--json] QUERY\n", cmdName)
+		fmt.Fprintf(out, "       %s status [-p PATH] [--json]\n", cmdName)
+		fmt.Fprintf(out, "       %s update [-p PATH] [--target TYPES] [--dry-run] [--no-pull]\n", cmdName)
+		fmt.Fprintf(out, "       %s prune [-r REPO] [-p PATH] [--dry-run] [-y]\n", cmdName)
+		fmt.Fprintf(out, "       %s completion bash|zsh|fish\n\n", cmdName)
 		fmt.Fprintln(out, "Run without options to open the interactive TUI installer.")
+		fmt.Fprintln(out, "Pass a SKILL name to install just that skill to every target using config defaults.")
 		fmt.Fprintln(out)
 		fmt.Fprintln(out, "Options:")
 		tw := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
-		fmt.Fprintln(tw, "  -r, --repo\tPath to skills repo (defaults to current directory)")
+		fmt.Fprintln(tw, "  -r, --repo\tPath to skills repo, a .tar.gz/.zip of one, or a git URL (defaults to current directory)")
+		fmt.Fprintln(tw, "  --repo-name\tInstall from the skills repo with this name in the config repos list")
 		fmt.Fprintln(tw, "  -p, --project\tProject path for project-local installs")
 		fmt.Fprintln(tw, "  -c, --copy\tCopy files instead of symlink")
 		fmt.Fprintln(tw, "  -s, --symlink\tForce symlink mode")
+		fmt.Fprintln(tw, "  --hardlink\tHard-link files instead of copying or symlinking")
 		fmt.Fprintln(tw, "  -f, --from-config\tInstall all skills using config defaults")
+		fmt.Fprintln(tw, "  --only-missing\tInstall only skills not already present in each target")
+		fmt.Fprintln(tw, "  --flat\tInstall single-file skills as <name>.md instead of a directory")
+		fmt.Fprintln(tw, "  --timeout\tAbort the whole run after this duration (e.g. 30s, 5m)")
+		fmt.Fprintln(tw, "  --ssh-key\tSSH private key to use when cloning the skills repo")
+		fmt.Fprintln(tw, "  --default-selection\tInitial TUI selection: all or none")
+		fmt.Fprintln(tw, "  --page-size\tItems per page in numeric selection prompts (0 shows all)")
+		fmt.Fprintln(tw, "  --release\tInstall a GitHub repo from its latest release tarball instead of cloning")
+		fmt.Fprintln(tw, "  --autodetect-skills-dir\tFind the skills directory when the repo has no skills/ folder")
+		fmt.Fprintln(tw, "  --check\tExit non-zero if any skill is missing or out of date in the targets")
+		fmt.Fprintln(tw, "  --strict\tExit non-zero if any warning was emitted")
+		fmt.Fprintln(tw, "  --gitignore\tAdd project installs to the project's .gitignore")
+		fmt.Fprintln(tw, "  --summary-only\tPrint only the end-of-run summary instead of per-skill lines")
+		fmt.Fprintln(tw, "  --home-user\tInstall into global targets under this user's home directory")
+		fmt.Fprintln(tw, "  --include-disabled\tOffer skills marked enabled: false or disabled: true (unchecked in the TUI)")
+		fmt.Fprintln(tw, "  --repo-root-marker\tBundled skills repo location relative to the executable (env: ASKILL_SKILLS_DIR)")
+		fmt.Fprintln(tw, "  --max-clone-size\tAsk before cloning GitHub repos larger than this (e.g. 200MB)")
+		fmt.Fprintln(tw, "  --target-priority\tComma-separated target types to install to first (ordering only)")
+		fmt.Fprintln(tw, "  --describe\tPrint why each skill is included or excluded and exit")
+		fmt.Fprintln(tw, "  --install-order\tOrder skills are installed in: alpha (default), priority, or deps")
+		fmt.Fprintln(tw, "  --no-overwrite-prompt\tFail without installing if any selected skill already exists")
+		fmt.Fprintln(tw, "  --skills\tComma-separated skill names or aliases to install without prompting")
+		fmt.Fprintln(tw, "  --single-file-skills\tDiscover *.md files with frontmatter in the skills root as skills")
+		fmt.Fprintln(tw, "  --sanitize-names\tReplace spaces and drop symbols such as emoji in installed names")
+		fmt.Fprintln(tw, "  --max-skills\tRefuse to install more than this many skills (0 means unlimited)")
+		fmt.Fprintln(tw, "  --no-dedupe-targets\tKeep targets that resolve to the same directory through symlinks")
+		fmt.Fprintln(tw, "  --report, --post-install-summary-file\tAlso write each skill, target, action, and mode as JSON to this file")
+		fmt.Fprintln(tw, "  --match\tOnly offer skills whose name, description, or tags contain this text (case-insensitive)")
+		fmt.Fprintln(tw, "  --tag\tOnly offer skills tagged with this tag (repeatable; all must match)")
+		fmt.Fprintln(tw, "  --category\tOnly offer skills in this frontmatter category")
+		fmt.Fprintln(tw, "  --from-lockfile\tInstall exactly the skills, targets, and modes recorded in this lockfile")
+		fmt.Fprintln(tw, "  --write-lockfile\tRecord the installed skills and their pinned sources in this lockfile")
+		fmt.Fprintln(tw, "  --link-all\tSymlink each target skills directory to the repo skills directory instead of installing per skill")
+		fmt.Fprintln(tw, "  -y, --yes\tOverwrite existing skills and select all targets (and all skills unless filtered) without prompting")
+		fmt.Fprintln(tw, "  --repo-token-file\tRead an access token for cloning private HTTPS repos from this file (env: ASKILL_GIT_TOKEN, GITHUB_TOKEN)")
+		fmt.Fprintln(tw, "  --refresh\tFetch cached remote repos before discovery, ignoring refresh-cooldown")
+		fmt.Fprintln(tw, "  --no-cache\tClone remote repos into a temp dir instead of the clone cache")
+		fmt.Fprintln(tw, "  --no-update-check\tSkip the check for a newer askill release")
+		fmt.Fprintln(tw, "  --stamp\tWrite the source repo URL and commit to .askill-source in each copied skill")
+		fmt.Fprintln(tw, "  --merge\tCopy installs overlay existing skill directories instead of replacing them")
+		fmt.Fprintln(tw, "  --dry-run\tPrint what would be installed where and exit without writing")
+		fmt.Fprintln(tw, "  --validate-targets\tWith --dry-run, check permissions, parents, and unmanaged conflicts per destination")
+		fmt.Fprintln(tw, "  --exclude\tLeave out the skill with this name or alias (repeatable)")
+		fmt.Fprintln(tw, "  --target\tInstall only to this target type, without prompting (repeatable)")
+		fmt.Fprintln(tw, "  -V, --verbose\tLog repo, config, skill, and target resolution and each install step to stderr (any command)")
+		fmt.Fprintln(tw, "  --config\tRead and write the config file at this path instead of the default (env: ASKILL_CONFIG; any command)")
+		fmt.Fprintln(tw, "  -q, --quiet\tHide per-skill lines and print one summary line; errors and warnings still print")
+		fmt.Fprintln(tw, "  --print-config-path\tPrint the config file path and exit")
 		fmt.Fprintln(tw, "  -v, --version\tPrint version and exit")
 		fmt.Fprintln(tw, "  -h, --help\tShow help")
 		_ = tw.Flush()
@@ -79,6 +281,9 @@ func Run(args []string, opts Options) error {
 		fmt.Fprintln(tw, "  Config path\t~/.config/askill/config.toml")
 		_ = tw.Flush()
 	}
+	if len(args) > 1 && args[1] == "completion" {
+		return runCompletionCommand(args[2:], cmdName, fs)
+	}
 	if err := fs.Parse(args[1:]); err != nil {
 		if errors.Is(err, flag.ErrHelp) {
 			return nil
@@ -86,10 +291,83 @@ func Run(args []string, opts Options) error {
 		return err
 	}
 
+	skillArg := ""
+	if fs.NArg() > 1 {
+		return fmt.Errorf("pass a single skill name, not %d; use --skills for several", fs.NArg())
+	}
+	if fs.NArg() == 1 {
+		skillArg = fs.Arg(0)
+		if skillNames != "" {
+			return errors.New("choose only one of --skills or a skill name argument")
+		}
+	}
+
 	if showVersion {
 		fmt.Printf("%s %s\n", cmdName, Version)
 		return nil
 	}
+	if printConfigPath {
+		path, err := configFilePath()
+		if err != nil {
+			return err
+		}
+		fmt.Println(path)
+		return nil
+	}
+	interactive := isInteractive(fs)
+	if interactive && (!isTerminal(os.Stdin) || !isTerminal(os.Stdout)) {
+		return fmt.Errorf("the interactive installer needs a terminal; pass a SKILL name, --from-config, or --skills with --target and --yes to install non-interactively (see %s --help)", cmdName)
+	}
+	report := &reporter{summaryOnly: summaryOnly, quiet: quiet}
+	if summaryPath != "" {
+		defer func() {
+			if writeErr := report.writeSummaryFile(summaryPath, err); writeErr != nil && err == nil {
+				err = writeErr
+			}
+		}()
+	}
+	if strict {
+		defer func() {
+			if err == nil {
+				err = report.strictError()
+			}
+		}()
+	}
+	if pageSize < 0 {
+		return errors.New("--page-size must not be negative")
+	}
+	if validateTargets && !dryRun {
+		return errors.New("--validate-targets only works with --dry-run")
+	}
+
+	if sshKey != "" {
+		if _, err := os.Stat(sshKey); err != nil {
+			return fmt.Errorf("ssh key: %w", err)
+		}
+	}
+	clone := cloneOptions{sshKey: sshKey, latestRelease: latestRelease}
+	if repoTokenFile != "" {
+		if clone.token, err = readTokenFile(repoTokenFile); err != nil {
+			return err
+		}
+	}
+	if clone = clone.withEnvToken(); clone.token != "" {
+		defer func() {
+			err = clone.redactError(err)
+		}()
+	}
+	if maxCloneSize != "" {
+		clone.maxSize, err = parseByteSize(maxCloneSize)
+		if err != nil {
+			return fmt.Errorf("invalid --max-clone-size: %w", err)
+		}
+	}
+
+	ctx, cancel := withTimeout(installer.WithLogger(context.Background(), debugf), timeout)
+	defer cancel()
+	defer func() {
+		err = timeoutError(err, timeout)
+	}()
 
 	root := repoRoot
 	project := projectPath
@@ -100,20 +378,96 @@ func Run(args []string, opts Options) error {
 	if symlinkMode {
 		mode = installer.ModeSymlink
 	}
+	if hardlinkMode {
+		mode = installer.ModeHardlink
+	}
 
-	defaultRoot, defaultRootErr := detectRepoRoot()
+	defaultRoot, defaultRootErr := detectRepoRoot(repoRootMarker)
+	if defaultRootErr != nil {
+		debugf("no bundled skills repo: %v", defaultRootErr)
+	} else {
+		debugf("bundled skills repo: %s", defaultRoot)
+	}
+	if repoRootMarker != "" && defaultRootErr != nil {
+		return fmt.Errorf("--repo-root-marker: %w", defaultRootErr)
+	}
 	cfg, cfgErr := loadConfig()
-	if (len(args) == 1 || fromConfig) && cfgErr != nil {
+	useConfig := fromConfig || skillArg != "" && repoRoot == "" && repoName == ""
+	if (interactive || useConfig) && cfgErr != nil {
 		return cfgErr
 	}
+	clone.refresh = refresh
+	clone.refreshOnStart = cfg.RefreshOnStart
+	if clone.refreshCooldown, err = refreshCooldown(cfg); err != nil {
+		return err
+	}
+	if clone.cacheTTL, err = cacheTTL(cfg); err != nil {
+		return err
+	}
+	clone.noCache = noCache
+	if noCache && refresh {
+		return errors.New("choose only one of --no-cache or --refresh")
+	}
+	if defaultSelection == "" {
+		defaultSelection = cfg.DefaultSelection
+	}
+	if err := validateDefaultSelection(defaultSelection); err != nil {
+		return err
+	}
+	targetOrder := cfg.TargetOrder
+	if targetPriority != "" {
+		targetOrder = splitList(targetPriority)
+	}
+	if _, err := orderTargets(nil, targetOrder); err != nil {
+		return err
+	}
+	for _, value := range targetTypes {
+		if !installer.IsTargetType(value) {
+			return fmt.Errorf("invalid --target %q: want one of %s", value, joinTargetTypes())
+		}
+	}
+	if err := validateInstallOrder(installOrder); err != nil {
+		return err
+	}
+
+	if repoName != "" {
+		if repoRoot != "" {
+			return errors.New("choose only one of --repo or --repo-name")
+		}
+		if _, ok := findRepo(cfg.Repos, repoName); !ok {
+			if len(cfg.Repos) == 0 {
+				return fmt.Errorf("--repo-name %q: no repos configured; add [[repos]] entries to the config", repoName)
+			}
+			return fmt.Errorf("--repo-name %q: unknown repo (configured: %s)", repoName, strings.Join(repoNames(cfg.Repos), ", "))
+		}
+		repoRoot = repoName
+	} else if repoRoot == "" && !interactive && fromLockfile == "" && strings.TrimSpace(cfg.SkillRepoPath) == "" {
+		if repoRoot, err = defaultRepoName(cfg.Repos); err != nil {
+			return err
+		}
+	}
 
-	if fromConfig {
+	if fromLockfile != "" {
+		if fromConfig {
+			return errors.New("choose only one of --from-config or --from-lockfile")
+		}
+		if cfgErr != nil {
+			return cfgErr
+		}
+		homeDir, err := resolveHomeDir(homeUser)
+		if err != nil {
+			return err
+		}
+		return installFromLockfile(ctx, clone, fromLockfile, homeDir, projectPath, cfg, report, dryRun, yes)
+	}
+
+	if useConfig {
 		cwd, err := os.Getwd()
 		if err != nil {
 			return fmt.Errorf("get working directory: %w", err)
 		}
 		defaultCfg := withDefaultConfig(cfg, defaultRoot, cwd)
-		resolvedRoot, cleanup, err := resolveSkillRepoPath(defaultCfg.SkillRepoPath, defaultRoot, cwd)
+		resolvedRoot, cleanup, err := resolveSkillRepoPath(ctx, clone, cfg.Repos, defaultCfg.SkillRepoPath, defaultRoot, cwd)
 		if err != nil {
 			return err
 		}
@@ -123,9 +477,12 @@ func Run(args []string, opts Options) error {
 		root = resolvedRoot
 		project = resolveProjectPath(defaultCfg, cwd)
 		mode = resolveInstallMode(defaultCfg)
-	} else if len(args) == 1 {
-		upgradeBanner := maybeUpgradeBanner(Version)
-		advanced, err := promptInstallFlowTUI(upgradeBanner)
+	} else if interactive {
+		upgradeBanner := ""
+		if !noUpdateCheck {
+			upgradeBanner = maybeUpgradeBanner(Version)
+		}
+		advanced, err := promptInstallFlowTUI(upgradeBanner, &cfg)
 		if err != nil {
 			return err
 		}
@@ -136,7 +493,7 @@ func Run(args []string, opts Options) error {
 				return fmt.Errorf("get working directory: %w", err)
 			}
 			defaultCfg := withDefaultConfig(cfg, defaultRoot, cwd)
-			resolvedRoot, cleanup, err := resolveSkillRepoPath(defaultCfg.SkillRepoPath, defaultRoot, cwd)
+			resolvedRoot, cleanup, err := resolveSkillRepoPath(ctx, clone, cfg.Repos, defaultCfg.SkillRepoPath, defaultRoot, cwd)
 			if err != nil {
 				return err
 			}
@@ -147,7 +504,7 @@ func Run(args []string, opts Options) error {
 			project = resolveProjectPath(defaultCfg, cwd)
 			mode = resolveInstallMode(defaultCfg)
 		} else {
-			selection, err := promptSourceSelectionTUI(defaultRoot, cfg)
+			selection, err := promptSourceSelectionTUI(ctx, clone, defaultRoot, cfg)
 			if err != nil {
 				return err
 			}
@@ -155,6 +512,9 @@ func Run(args []string, opts Options) error {
 			if selection.cleanup != nil {
 				defer selection.cleanup()
 			}
+			if selection.skillsRoot {
+				autodetectSkillsDir = true
+			}
 			cfgPrompt, err := promptConfigTUI(root, cfg)
 			if err != nil {
 				return err
@@ -167,13 +527,13 @@ func Run(args []string, opts Options) error {
 		return cfgErr
 	}
 
-	if copyMode && symlinkMode {
-		return errors.New("choose only one of --copy or --symlink")
+	if yes && noOverwritePrompt {
+		return errors.New("choose only one of --yes or --no-overwrite-prompt")
 	}
-
-	if repoRoot != "" {
-		root = repoRoot
+	if countTrue(copyMode, symlinkMode, hardlinkMode) > 1 {
+		return errors.New("choose only one of --copy, --symlink, or --hardlink")
 	}
+
 	if projectPath != "" {
 		project = projectPath
 	}
@@ -183,6 +543,12 @@ func Run(args []string, opts Options) error {
 	if symlinkMode {
 		mode = installer.ModeSymlink
 	}
+	if hardlinkMode {
+		mode = installer.ModeHardlink
+	}
+	if merge && mode != installer.ModeCopy {
+		return errors.New("--merge only works with copy installs")
+	}
 
 	if root == "" {
 		if defaultRootErr == nil && defaultRoot != "" {
@@ -190,108 +556,369 @@ func Run(args []string, opts Options) error {
 		}
 	}
 
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("get working directory: %w", err)
+	}
 	if root == "" {
-		cwd, err := os.Getwd()
-		if err != nil {
-			return fmt.Errorf("get working directory: %w", err)
-		}
 		root = cwd
 	}
 
-	skillsRoot := filepath.Join(root, "skills")
-	skills, err := installer.DiscoverSkills(skillsRoot)
+	roots := []string{root}
+	if repoRoot != "" {
+		roots = nil
+		for _, value := range splitList(repoRoot) {
+			if mode == installer.ModeSymlink && isSkillsArchive(value) {
+				fmt.Println("Note: installing from an archive uses copy mode; symlinks into the extracted files would dangle")
+				mode = installer.ModeCopy
+			}
+			resolved, cleanup, err := resolveSkillRepoPath(ctx, clone, cfg.Repos, value, defaultRoot, cwd)
+			if err != nil {
+				return err
+			}
+			if cleanup != nil {
+				defer cleanup()
+			}
+			roots = append(roots, resolved)
+		}
+		if len(roots) == 0 {
+			return errors.New("--repo is empty")
+		}
+	}
+
+	discovery := discoverOptions{
+		autodetect:      autodetectSkillsDir,
+		singleFile:      singleFileSkills,
+		descriptionFile: descriptionFile(cfg),
+	}
+	skills, err := discoverSkillsInRoots(roots, discovery, report)
 	if err != nil {
-		return fmt.Errorf("discover skills: %w", err)
+		return err
+	}
+	decisions := newSkillDecisions(skills)
+	if !includeDisabled {
+		excludeSkills(decisions, "disabled in frontmatter", func(skill installer.Skill) bool { return !skill.Enabled })
+	}
+	if skillArg != "" {
+		named, err := findNamedSkill(skills, roots, skillArg)
+		if err != nil {
+			return err
+		}
+		excludeSkills(decisions, "not the named skill", func(skill installer.Skill) bool { return skill.Path != named.Path })
+	}
+	if skillNames != "" {
+		names := splitList(skillNames)
+		if unmatched := unmatchedNames(skills, names); len(unmatched) > 0 {
+			return fmt.Errorf("--skills: no skill named %s", strings.Join(unmatched, ", "))
+		}
+		excludeSkills(decisions, "not named in --skills", func(skill installer.Skill) bool {
+			for _, name := range names {
+				if skillMatches(skill, name) {
+					return false
+				}
+			}
+			return true
+		})
+	}
+	for _, name := range excludes {
+		if len(unmatchedNames(skills, []string{name})) > 0 {
+			report.warnf("--exclude %s matched no skill", name)
+			continue
+		}
+		excludeSkills(decisions, fmt.Sprintf("excluded by --exclude %s", name), func(skill installer.Skill) bool { return skillMatches(skill, name) })
+	}
+	if match != "" {
+		excludeSkills(decisions, fmt.Sprintf("does not match --match %q", match), func(skill installer.Skill) bool {
+			return !skillMatchesText(skill, match)
+		})
+	}
+	for _, tag := range tags {
+		if err := filterByField(decisions, "--tag", tag, func(skill installer.Skill) bool { return hasTag(skill, tag) }); err != nil {
+			return err
+		}
+	}
+	if category != "" {
+		if err := filterByField(decisions, "--category", category, func(skill installer.Skill) bool {
+			return strings.EqualFold(skill.Category, category)
+		}); err != nil {
+			return err
+		}
+	}
+	for _, decision := range decisions {
+		if !decision.included {
+			debugf("skill %s excluded: %s", decision.skill.Name, decision.reason)
+		}
+	}
+	if describe {
+		return printDecisions(decisions)
+	}
+	skills = includedSkills(decisions)
+	if len(skills) == 0 {
+		if match != "" {
+			return fmt.Errorf("--match %q: no skill matches; pass --describe to see why", match)
+		}
+		return errors.New("no skills left after filtering; pass --describe to see why")
 	}
 
-	homeDir, err := os.UserHomeDir()
+	homeDir, err := resolveHomeDir(homeUser)
 	if err != nil {
-		return fmt.Errorf("determine home directory: %w", err)
+		return err
 	}
 
-	targets := installer.DiscoverTargets(homeDir, project)
+	targets, err := discoverTargets(homeDir, project, cfg, !noDedupeTargets)
+	if err != nil {
+		return err
+	}
 	if len(targets) == 0 {
 		return fmt.Errorf("no install targets found under %s. Create a harness folder or pass --project", homeDir)
 	}
+	if targets, err = selectTargetTypes(targets, targetTypes); err != nil {
+		return err
+	}
+	for _, target := range targets {
+		debugf("target %s at %s", target.Type, target.Path)
+	}
 
 	sort.Slice(skills, func(i, j int) bool { return skills[i].Name < skills[j].Name })
+	dests := destOptions{flat: flat, sanitize: sanitizeNames}
 
-	var overwriteAll bool
-	selectedTargets := targets
-	if len(args) == 1 {
-		indices, err := selectIndicesTUI("Select install targets", targetsSummary(targets), defaultSelectAll(len(targets)), false)
-		if err != nil {
-			if errors.Is(err, errCanceled) {
-				return nil
-			}
-			return err
+	if check {
+		return reportCheck(buildPlan(targets, skills, mode, dests))
+	}
+
+	if linkAll {
+		if len(roots) != 1 {
+			return errors.New("--link-all needs a single skills repo")
 		}
-		selectedTargets = filterTargets(targets, indices)
-		if len(selectedTargets) == 0 {
-			return errors.New("no targets selected")
+		if copyMode || hardlinkMode {
+			return errors.New("--link-all always symlinks; drop --copy/--hardlink")
 		}
-		overwriteAll, err = promptOverwriteTUI()
-		if err != nil {
-			if errors.Is(err, errCanceled) {
-				return nil
+		skillsRoot := filepath.Join(roots[0], "skills")
+		if autodetectSkillsDir {
+			if skillsRoot, err = installer.DetectSkillsDir(roots[0]); err != nil {
+				return fmt.Errorf("discover skills: %w", err)
 			}
-			return err
 		}
-	} else if len(targets) > 1 {
-		indices := promptIndices("Select install targets (e.g. 1,3):", targetsSummary(targets))
-		selectedTargets = filterTargets(targets, indices)
-		if len(selectedTargets) == 0 {
-			return errors.New("no targets selected")
+		selectedTargets := targets
+		if len(targets) > 1 && !yes && len(targetTypes) == 0 {
+			indices := promptIndices("Select targets to link (e.g. 1,3):", targetsSummary(targets), pageSize)
+			selectedTargets = filterTargets(targets, indices)
+			if len(selectedTargets) == 0 {
+				return errors.New("no targets selected")
+			}
 		}
+		return linkAllTargets(selectedTargets, skillsRoot, report, dryRun)
 	}
 
+	var overwriteAll bool
+	selectedTargets := targets
 	selectedSkills := skills
-	var indices []int
-	var skillsErr error
-	if len(args) == 1 {
-		indices, skillsErr = selectIndicesTUI("Select skills to install", skillsSummary(skills), defaultSelectAll(len(skills)), false)
-		if skillsErr != nil {
-			if errors.Is(skillsErr, errCanceled) {
+	if interactive {
+		selectedTargets, overwriteAll, selectedSkills, err = selectInteractively(targets, skills, defaultSelection)
+		if err != nil {
+			if errors.Is(err, errCanceled) {
 				return nil
 			}
-			return skillsErr
+			return err
 		}
 	} else {
-		indices = promptIndices("Select skills to install (e.g. 1,2,5):", skillsSummary(skills))
+		if len(targets) > 1 && !yes && skillArg == "" && len(targetTypes) == 0 {
+			indices := promptIndices("Select install targets (e.g. 1,3):", targetsSummary(targets), pageSize)
+			selectedTargets = filterTargets(targets, indices)
+			if len(selectedTargets) == 0 {
+				return errors.New("no targets selected")
+			}
+		}
+		if skillNames == "" && skillArg == "" && match == "" && !yes {
+			indices := promptIndices("Select skills to install (e.g. 1,2,5):", skillsSummary(skills), pageSize)
+			selectedSkills = filterSkills(skills, indices)
+		}
 	}
-	selectedSkills = filterSkills(skills, indices)
 	if len(selectedSkills) == 0 {
 		return errors.New("no skills selected")
 	}
+	if maxSkills == 0 {
+		maxSkills = cfg.MaxSkills
+	}
+	if maxSkills > 0 && len(selectedSkills) > maxSkills {
+		prompt := fmt.Sprintf("%d skills selected, above the limit of %d. Install anyway? [y/N]: ", len(selectedSkills), maxSkills)
+		if !isTerminal(os.Stdin) || !confirm(stdinReader, prompt) {
+			return fmt.Errorf("%d skills selected, above the limit of %d; check --repo or raise --max-skills", len(selectedSkills), maxSkills)
+		}
+	}
 
-	reader := bufio.NewReader(os.Stdin)
+	selectedTargets, err = orderTargets(selectedTargets, targetOrder)
+	if err != nil {
+		return err
+	}
+	selectedSkills, err = orderSkills(selectedSkills, installOrder, report)
+	if err != nil {
+		return err
+	}
+	plan := buildPlan(selectedTargets, selectedSkills, mode, dests)
+	if err := checkDestinationConflicts(plan); err != nil {
+		return err
+	}
+	if dryRun {
+		return reportDryRun(plan, onlyMissing, validateTargets)
+	}
+	if message, ok := nothingToDo(plan, onlyMissing); ok {
+		fmt.Println(message)
+		if writeLockfilePath != "" {
+			return writeInstallLockfile(ctx, writeLockfilePath, roots, project, plan, mode)
+		}
+		return nil
+	}
+	if noOverwritePrompt {
+		if err := checkNoExisting(selectedTargets, selectedSkills, dests, onlyMissing); err != nil {
+			return err
+		}
+	}
+
+	installCtx := ctx
+	if isTerminal(os.Stderr) && !report.terse() {
+		installCtx = installer.WithProgress(ctx, copyProgress(os.Stderr))
+	}
+	var stampSources []lockSource
+	if stamp {
+		if mode == installer.ModeSymlink {
+			fmt.Println("Note: --stamp is skipped for symlink installs; they always reflect the source")
+		} else {
+			stampSources = lockSources(ctx, roots)
+		}
+	}
+	journal := newInstallJournal()
+	defer func() {
+		journal.finish(ctx, err, report)
+	}()
+	var ignoreEntries []string
+	var locked []planItem
 	for _, target := range selectedTargets {
 		if err := os.MkdirAll(target.Path, 0o755); err != nil {
 			return fmt.Errorf("create target %s: %w", target.Path, err)
 		}
-		for _, skill := range selectedSkills {
-			dest := filepath.Join(target.Path, filepath.Base(skill.Path))
-			if _, err := os.Lstat(dest); err == nil {
-				if len(args) == 1 {
+		targetSkills := selectedSkills
+		present, installed := 0, 0
+		if onlyMissing {
+			targetSkills, present = missingSkills(target, selectedSkills, dests)
+		}
+		for _, skill := range targetSkills {
+			layout := skillLayout(skill, dests)
+			dest := skillDestination(target, skill, layout, dests)
+			src := skillSource(skill, layout)
+			result := installResult{
+				Skill:  skill.Name,
+				Target: target.Type,
+				Path:   dest,
+				Action: actionInstalled,
+				Mode:   mode,
+				label:  target.Label,
+			}
+			if err := installer.CheckDistinct(src, dest); err != nil {
+				return report.fail(result, fmt.Errorf("install %s to %s: %w", skill.Name, target.Label, err))
+			}
+			if mode == installer.ModeSymlink && installer.IsSymlinkTo(dest, src) {
+				if !installer.HasMeta(dest) {
+					meta := installer.Meta{Skill: skill.Name, Source: skill.Path, Mode: mode, Layout: layout, Version: skill.Version}
+					if err := installer.WriteMeta(dest, meta); err != nil {
+						return report.fail(result, fmt.Errorf("write meta for %s: %w", dest, err))
+					}
+				}
+				result.Action = actionSkipped
+				result.upToDate = true
+				report.record(result)
+				locked = append(locked, planItem{skill: skill, target: target, dest: dest, layout: layout})
+				continue
+			}
+			if info, err := os.Lstat(dest); err == nil {
+				if interactive {
 					if !overwriteAll {
-						fmt.Printf("Skipping %s for %s\n", skill.Name, target.Label)
+						result.Action = actionSkipped
+						report.record(result)
 						continue
 					}
-				} else if !confirm(reader, fmt.Sprintf("%s exists in %s. Overwrite? [y/N]: ", filepath.Base(skill.Path), target.Label)) {
-					fmt.Printf("Skipping %s for %s\n", skill.Name, target.Label)
+				} else if !yes && !confirm(stdinReader, overwritePrompt(dest, info, target.Label, mode)) {
+					result.Action = actionSkipped
+					report.record(result)
 					continue
 				}
-				if err := os.RemoveAll(dest); err != nil {
-					return fmt.Errorf("remove existing %s: %w", dest, err)
+				result.Action = actionOverwritten
+			}
+			merged := false
+			if info, err := os.Lstat(dest); err == nil && info.IsDir() && merge && layout == installer.LayoutDir {
+				merged = true
+				conflicts, err := installer.MergeConflicts(skill.Path, dest)
+				if err != nil {
+					return report.fail(result, fmt.Errorf("merge %s into %s: %w", skill.Name, dest, err))
+				}
+				for _, conflict := range conflicts {
+					report.warnf("merge %s: overwriting %s", dest, conflict)
+				}
+			}
+			if err := journal.prepare(ctx, dest, merged); err != nil {
+				return report.fail(result, err)
+			}
+			var installErr error
+			switch {
+			case layout == installer.LayoutFlat:
+				debugf("install %s to %s (%s)", src, dest, mode)
+				installErr = installer.InstallSkillFile(src, dest, mode)
+			case merged:
+				installErr = installer.MergeSkillContext(installCtx, skill.Path, dest)
+			default:
+				installErr = installer.InstallSkillContext(installCtx, skill.Path, dest, mode)
+			}
+			if installErr == nil {
+				installErr = installer.VerifyInstall(dest, layout)
+			}
+			if installErr != nil {
+				return report.fail(result, fmt.Errorf("install %s to %s: %w", skill.Name, target.Label, installErr))
+			}
+			meta := installer.Meta{Skill: skill.Name, Source: skill.Path, Mode: mode, Layout: layout, Version: skill.Version, Merged: merged}
+			if base := installer.InstallName(skill); dests.sanitize && base != filepath.Base(dest) {
+				meta.OriginalName = base
+			}
+			debugf("write %s", installer.MetaPath(dest))
+			if err := installer.WriteMeta(dest, meta); err != nil {
+				return report.fail(result, fmt.Errorf("write meta for %s: %w", dest, err))
+			}
+			if stampSources != nil && layout == installer.LayoutDir {
+				if err := writeSourceStamp(dest, stampSources, skill); err != nil {
+					return report.fail(result, fmt.Errorf("write source stamp for %s: %w", dest, err))
 				}
 			}
-			if err := installer.InstallSkill(skill.Path, dest, mode); err != nil {
-				return fmt.Errorf("install %s to %s: %w", skill.Name, target.Label, err)
+			journal.complete()
+			report.record(result)
+			installed++
+			locked = append(locked, planItem{skill: skill, target: target, dest: dest, layout: layout})
+			if gitignore && installer.IsProjectTarget(target.Type) {
+				ignoreEntries = append(ignoreEntries, gitignoreEntries(project, dest, mode, layout)...)
 			}
-			fmt.Printf("Installed %s to %s (%s)\n", skill.Name, target.Label, mode)
+		}
+		if onlyMissing && !report.terse() {
+			fmt.Printf("%s: %d already present, %d installed\n", target.Label, present, installed)
+		}
+	}
+
+	if len(ignoreEntries) > 0 {
+		added, err := ensureGitignoreEntries(project, ignoreEntries)
+		if err != nil {
+			return fmt.Errorf("update .gitignore: %w", err)
+		}
+		if added > 0 {
+			fmt.Printf("Added %d entries to %s\n", added, filepath.Join(project, ".gitignore"))
+		}
+	}
+
+	if writeLockfilePath != "" {
+		if err := writeInstallLockfile(ctx, writeLockfilePath, roots, project, locked, mode); err != nil {
+			return err
 		}
 	}
 
+	if report.terse() {
+		report.printSummary()
+	}
 	return nil
 }
 
@@ -302,15 +929,30 @@ type config struct {
 }
 
 type appConfig struct {
-	SkillRepoPath string `toml:"skill-repo-path"`
-	ProjectChoice string `toml:"project-choice"`
-	ProjectPath   string `toml:"project-path"`
-	InstallMode   string `toml:"install-mode"`
+	SkillRepoPath    string            `toml:"skill-repo-path" json:"skill-repo-path"`
+	ProjectChoice    string            `toml:"project-choice" json:"project-choice"`
+	ProjectPath      string            `toml:"project-path" json:"project-path"`
+	InstallMode      string            `toml:"install-mode" json:"install-mode"`
+	DefaultSelection string            `toml:"default-selection" json:"default-selection"`
+	TargetSubdirs    map[string]string `toml:"target-subdirs" json:"target-subdirs"`
+	TargetOrder      []string          `toml:"target-order" json:"target-order"`
+	MaxSkills        int               `toml:"max-skills" json:"max-skills"`
+	DescriptionFile  string            `toml:"description-file" json:"description-file"`
+	Repos            []namedRepo       `toml:"repos" json:"repos"`
+	RefreshOnStart   bool              `toml:"refresh-on-start" json:"refresh-on-start"`
+	RefreshCooldown  string            `toml:"refresh-cooldown" json:"refresh-cooldown"`
+	CacheTTL         string            `toml:"cache-ttl" json:"cache-ttl"`
+}
+
+type namedRepo struct {
+	Name string `toml:"name" json:"name"`
+	Path string `toml:"path" json:"path"`
 }
 
 type configSelection struct {
-	root    string
-	cleanup func()
+	root       string
+	cleanup    func()
+	skillsRoot bool
 }
 
 func promptConfigTUI(root string, cfg appConfig) (config, error) {
@@ -333,15 +975,99 @@ func promptConfigTUI(root string, cfg appConfig) (config, error) {
 	}, nil
 }
 
-func promptIndices(prompt string, items []string) []int {
-	reader := bufio.NewReader(os.Stdin)
+func splitList(value string) []string {
+	var out []string
+	for _, part := range strings.Split(value, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			out = append(out, part)
+		}
+	}
+	return out
+}
+
+type stringsFlag []string
+
+func (f *stringsFlag) String() string {
+	return strings.Join(*f, ",")
+}
+
+func (f *stringsFlag) Set(value string) error {
+	*f = append(*f, value)
+	return nil
+}
+
+type discoverOptions struct {
+	autodetect      bool
+	singleFile      bool
+	descriptionFile string
+}
+
+func descriptionFile(cfg appConfig) string {
+	if name := strings.TrimSpace(cfg.DescriptionFile); name != "" {
+		return name
+	}
+	return installer.DefaultDescriptionFile
+}
+
+func discoverSkillsInRoots(roots []string, opts discoverOptions, report *reporter) ([]installer.Skill, error) {
+	groups := make([][]installer.Skill, 0, len(roots))
+	for _, root := range roots {
+		skillsRoot := filepath.Join(root, "skills")
+		if !opts.autodetect && installer.ExistsDir(root) && !installer.ExistsDir(skillsRoot) {
+			return nil, fmt.Errorf("%s has no skills/ folder; pass --autodetect-skills-dir to use it as a skills root anyway", root)
+		}
+		if opts.autodetect {
+			detected, err := installer.DetectSkillsDir(root)
+			if err != nil {
+				return nil, fmt.Errorf("discover skills: %w", err)
+			}
+			if detected != skillsRoot {
+				fmt.Printf("Using skills directory %s\n", detected)
+			}
+			skillsRoot = detected
+		}
+		var skills []installer.Skill
+		var err error
+		if opts.singleFile {
+			skills, err = installer.DiscoverFileSkills(skillsRoot)
+		} else {
+			skills, err = installer.DiscoverSkillsWithDescriptionFile(skillsRoot, opts.descriptionFile)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("discover skills in %s: %w", root, err)
+		}
+		for _, skill := range skills {
+			debugf("discovered skill %s at %s", skill.Name, skill.Path)
+		}
+		groups = append(groups, skills)
+	}
+	skills, duplicates := installer.MergeSkills(groups...)
+	for _, dup := range duplicates {
+		report.warnf("skill %s in %s overrides %s", dup.Name, dup.Kept, dup.Replaced)
+	}
+	warnAliasCollisions(skills, report)
+	return skills, nil
+}
+
+func promptIndices(prompt string, items []string, pageSize int) []int {
+	reader := stdinReader
 	fmt.Println(prompt)
+	text := ""
 	for i, item := range items {
+		if pageSize > 0 && i > 0 && i%pageSize == 0 {
+			fmt.Printf("-- %d more: press enter to continue or type your selection --\n", len(items)-i)
+			line, _ := reader.ReadString('\n')
+			if text = strings.TrimSpace(line); text != "" {
+				break
+			}
+		}
 		fmt.Printf("%d) %s\n", i+1, item)
 	}
-	fmt.Print("> ")
-	text, _ := reader.ReadString('\n')
-	text = strings.TrimSpace(text)
+	if text == "" {
+		fmt.Print("> ")
+		line, _ := reader.ReadString('\n')
+		text = strings.TrimSpace(line)
+	}
 	if text == "" {
 		return nil
 	}
@@ -361,7 +1087,7 @@ func promptIndices(prompt string, items []string) []int {
 	return indices
 }
 
-func detectRepoRoot() (string, error) {
+func detectRepoRoot(marker string) (string, error) {
 	executable, err := os.Executable()
 	if err != nil {
 		return "", err
@@ -371,13 +1097,38 @@ func detectRepoRoot() (string, error) {
 		executable = resolved
 	}
 	exeDir := filepath.Dir(executable)
+	if marker == "" {
+		marker = os.Getenv("ASKILL_SKILLS_DIR")
+	}
+	if marker != "" {
+		root := marker
+		if !filepath.IsAbs(root) {
+			root = filepath.Join(exeDir, root)
+		}
+		root = filepath.Clean(root)
+		debugf("repo root marker %s resolves to %s", marker, root)
+		if !installer.ExistsDir(filepath.Join(root, "skills")) {
+			return "", fmt.Errorf("no skills directory found under %s", root)
+		}
+		return root, nil
+	}
 	sharedSkills := filepath.Clean(filepath.Join(exeDir, "..", "share", "askill", "skills"))
+	debugf("looking for bundled skills in %s", sharedSkills)
 	if installer.ExistsDir(sharedSkills) {
 		return filepath.Dir(sharedSkills), nil
 	}
 	return "", errors.New("no bundled skills path found")
 }
 
+var stdinReader = bufio.NewReader(os.Stdin)
+
+func overwritePrompt(dest string, info os.FileInfo, label string, mode installer.Mode) string {
+	if info.Mode()&os.ModeSymlink == 0 && mode == installer.ModeSymlink {
+		return fmt.Sprintf("%s in %s is a real directory, not a symlink; local edits will be lost. Overwrite? [y/N]: ", filepath.Base(dest), label)
+	}
+	return fmt.Sprintf("%s exists in %s. Overwrite? [y/N]: ", filepath.Base(dest), label)
+}
+
 func confirm(reader *bufio.Reader, prompt string) bool {
 	fmt.Print(prompt)
 	text, _ := reader.ReadString('\n')
@@ -385,54 +1136,361 @@ func confirm(reader *bufio.Reader, prompt string) bool {
 	return text == "y" || text == "yes"
 }
 
-func targetsSummary(targets []installer.Target) []string {
-	items := make([]string, 0, len(targets))
-	for _, target := range targets {
-		status := "missing (will create)"
-		if target.Exists {
-			status = "found"
+func targetsSummary(targets []installer.Target) []string {
+	items := make([]string, 0, len(targets))
+	for _, target := range targets {
+		status := "missing (will create)"
+		if target.Exists {
+			status = "found"
+		}
+		items = append(items, fmt.Sprintf("%s - %s (%s)", target.Label, target.Path, status))
+	}
+	return items
+}
+
+func skillsSummary(skills []installer.Skill) []string {
+	items := make([]string, 0, len(skills))
+	for _, skill := range skills {
+		desc := strings.TrimSpace(skill.Description)
+		if desc == "" {
+			desc = "no description"
+		}
+		item := fmt.Sprintf("%s - %s", skill.Name, desc)
+		if len(skill.Tags) > 0 {
+			item += " " + helpStyle.Render("["+strings.Join(skill.Tags, ", ")+"]")
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+func skillFiles(skills []installer.Skill) []string {
+	paths := make([]string, 0, len(skills))
+	for _, skill := range skills {
+		if strings.EqualFold(filepath.Ext(skill.Path), ".md") {
+			paths = append(paths, skill.Path)
+		} else {
+			paths = append(paths, filepath.Join(skill.Path, "SKILL.md"))
+		}
+	}
+	return paths
+}
+
+func filterTargets(targets []installer.Target, indices []int) []installer.Target {
+	if len(indices) == 0 {
+		return nil
+	}
+	var out []installer.Target
+	for _, idx := range indices {
+		if idx >= 0 && idx < len(targets) {
+			out = append(out, targets[idx])
+		}
+	}
+	return out
+}
+
+func filterSkills(skills []installer.Skill, indices []int) []installer.Skill {
+	if len(indices) == 0 {
+		return nil
+	}
+	var out []installer.Skill
+	for _, idx := range indices {
+		if idx >= 0 && idx < len(skills) {
+			out = append(out, skills[idx])
+		}
+	}
+	return out
+}
+
+func resolveHomeDir(username string) (string, error) {
+	if username == "" {
+		homeDir, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("determine home directory: %w", err)
+		}
+		return homeDir, nil
+	}
+	u, err := user.Lookup(username)
+	if err != nil {
+		return "", fmt.Errorf("look up user %s: %w", username, err)
+	}
+	if _, err := os.Stat(u.HomeDir); err != nil {
+		if errors.Is(err, os.ErrPermission) {
+			return "", fmt.Errorf("cannot access home directory %s of user %s: permission denied (run as that user or with elevated privileges)", u.HomeDir, username)
+		}
+		return "", fmt.Errorf("home directory of user %s: %w", username, err)
+	}
+	return u.HomeDir, nil
+}
+
+func discoverTargets(homeDir, project string, cfg appConfig, dedupe bool) ([]installer.Target, error) {
+	subdirs, err := targetSubdirs(cfg)
+	if err != nil {
+		return nil, err
+	}
+	targets := installer.DiscoverTargetsWithSubdirs(homeDir, project, subdirs)
+	if dedupe {
+		targets = installer.DedupeTargetsByRealPath(targets)
+	}
+	return targets, nil
+}
+
+func orderTargets(targets []installer.Target, order []string) ([]installer.Target, error) {
+	if len(order) == 0 {
+		return targets, nil
+	}
+	rank := make(map[installer.TargetType]int, len(order))
+	for i, value := range order {
+		if !installer.IsTargetType(value) {
+			return nil, fmt.Errorf("invalid target type %q in target order: want one of %s", value, joinTargetTypes())
+		}
+		if _, ok := rank[installer.TargetType(value)]; !ok {
+			rank[installer.TargetType(value)] = i
+		}
+	}
+	position := func(t installer.TargetType) int {
+		if i, ok := rank[t]; ok {
+			return i
+		}
+		return len(order)
+	}
+	ordered := append([]installer.Target(nil), targets...)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return position(ordered[i].Type) < position(ordered[j].Type)
+	})
+	return ordered, nil
+}
+
+func targetSubdirs(cfg appConfig) (map[installer.TargetType]string, error) {
+	if len(cfg.TargetSubdirs) == 0 {
+		return nil, nil
+	}
+	subdirs := make(map[installer.TargetType]string, len(cfg.TargetSubdirs))
+	for key, value := range cfg.TargetSubdirs {
+		if !installer.IsTargetType(key) {
+			return nil, fmt.Errorf("invalid target-subdirs key %q: want one of %s", key, joinTargetTypes())
+		}
+		value = strings.TrimSpace(value)
+		if value == "" || filepath.IsAbs(value) {
+			return nil, fmt.Errorf("invalid target-subdirs value for %s: %q must be a relative path", key, value)
+		}
+		subdirs[installer.TargetType(key)] = value
+	}
+	return subdirs, nil
+}
+
+func selectTargetTypes(targets []installer.Target, types []string) ([]installer.Target, error) {
+	if len(types) == 0 {
+		return targets, nil
+	}
+	var selected []installer.Target
+	seen := make(map[string]bool, len(types))
+	for _, value := range types {
+		if seen[value] {
+			continue
+		}
+		seen[value] = true
+		found := false
+		for _, target := range targets {
+			if string(target.Type) == value {
+				selected = append(selected, target)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("--target %s: target not found; create its harness folder or pass --project for project targets", value)
+		}
+	}
+	return selected, nil
+}
+
+func joinTargetTypes() string {
+	types := installer.TargetTypes()
+	names := make([]string, 0, len(types))
+	for _, t := range types {
+		names = append(names, string(t))
+	}
+	return strings.Join(names, "|")
+}
+
+type destOptions struct {
+	flat     bool
+	sanitize bool
+}
+
+func skillLayout(skill installer.Skill, opts destOptions) installer.Layout {
+	if installer.IsFileSkill(skill.Path) || opts.flat && installer.IsSingleFileSkill(skill.Path) {
+		return installer.LayoutFlat
+	}
+	return installer.LayoutDir
+}
+
+func skillSource(skill installer.Skill, layout installer.Layout) string {
+	if layout == installer.LayoutFlat && !installer.IsFileSkill(skill.Path) {
+		return filepath.Join(skill.Path, "SKILL.md")
+	}
+	return skill.Path
+}
+
+func skillDestination(target installer.Target, skill installer.Skill, layout installer.Layout, opts destOptions) string {
+	name := installer.InstallName(skill)
+	if opts.sanitize {
+		name = sanitizeName(name)
+	}
+	if layout == installer.LayoutFlat && !installer.IsFileSkill(skill.Path) {
+		name += ".md"
+	}
+	return filepath.Join(target.Path, name)
+}
+
+func sanitizeName(name string) string {
+	ext := ""
+	if strings.HasSuffix(name, ".md") {
+		name, ext = strings.TrimSuffix(name, ".md"), ".md"
+	}
+	var b strings.Builder
+	dash := false
+	for _, r := range name {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' || r == '.':
+			b.WriteRune(r)
+			dash = false
+		case unicode.IsSpace(r) || r == '-':
+			if !dash && b.Len() > 0 {
+				b.WriteRune('-')
+				dash = true
+			}
 		}
-		items = append(items, fmt.Sprintf("%s - %s (%s)", target.Label, target.Path, status))
 	}
-	return items
+	clean := strings.TrimRight(b.String(), "-")
+	if clean == "" {
+		clean = "skill"
+	}
+	return clean + ext
 }
 
-func skillsSummary(skills []installer.Skill) []string {
-	items := make([]string, 0, len(skills))
+func missingSkills(target installer.Target, skills []installer.Skill, opts destOptions) ([]installer.Skill, int) {
+	var missing []installer.Skill
+	present := 0
 	for _, skill := range skills {
-		desc := strings.TrimSpace(skill.Description)
-		if desc == "" {
-			desc = "no description"
+		if installer.IsManagedSkill(skillDestination(target, skill, skillLayout(skill, opts), opts)) {
+			present++
+			continue
 		}
-		items = append(items, fmt.Sprintf("%s - %s", skill.Name, desc))
+		missing = append(missing, skill)
 	}
-	return items
+	return missing, present
 }
 
-func filterTargets(targets []installer.Target, indices []int) []installer.Target {
-	if len(indices) == 0 {
+func checkNoExisting(targets []installer.Target, skills []installer.Skill, opts destOptions, onlyMissing bool) error {
+	var existing []string
+	for _, target := range targets {
+		for _, skill := range skills {
+			dest := skillDestination(target, skill, skillLayout(skill, opts), opts)
+			if onlyMissing && installer.IsManagedSkill(dest) {
+				continue
+			}
+			if _, err := os.Lstat(dest); err == nil {
+				existing = append(existing, fmt.Sprintf("%s in %s (%s)", skill.Name, target.Label, dest))
+			}
+		}
+	}
+	if len(existing) == 0 {
 		return nil
 	}
-	var out []installer.Target
-	for _, idx := range indices {
-		if idx >= 0 && idx < len(targets) {
-			out = append(out, targets[idx])
-		}
+	return fmt.Errorf("--no-overwrite-prompt: %d selected skill(s) already exist, nothing was installed:\n  %s", len(existing), strings.Join(existing, "\n  "))
+}
+
+var uiOnlyFlags = map[string]bool{
+	"default-selection": true,
+	"match":             true,
+	"tag":               true,
+	"category":          true,
+	"exclude":           true,
+	"include-disabled":  true,
+	"no-update-check":   true,
+}
+
+func isInteractive(fs *flag.FlagSet) bool {
+	if fs.NArg() > 0 {
+		return false
 	}
-	return out
+	interactive := true
+	fs.Visit(func(f *flag.Flag) {
+		if !uiOnlyFlags[f.Name] {
+			interactive = false
+		}
+	})
+	return interactive
 }
 
-func filterSkills(skills []installer.Skill, indices []int) []installer.Skill {
-	if len(indices) == 0 {
+func validateDefaultSelection(value string) error {
+	switch value {
+	case "", "all", "none":
 		return nil
+	default:
+		return fmt.Errorf("invalid default-selection %q: want all|none", value)
 	}
-	var out []installer.Skill
-	for _, idx := range indices {
-		if idx >= 0 && idx < len(skills) {
-			out = append(out, skills[idx])
+}
+
+func selectInteractively(targets []installer.Target, skills []installer.Skill, defaultSelection string) ([]installer.Target, bool, []installer.Skill, error) {
+	targetSelection := initialSelection(defaultSelection, len(targets))
+	skillSelection := initialSelection(defaultSelection, len(skills))
+	for i, skill := range skills {
+		if !skill.Enabled {
+			delete(skillSelection, i)
 		}
 	}
-	return out
+	var selectedTargets []installer.Target
+	var selectedSkills []installer.Skill
+	var overwriteAll bool
+	for step := 0; step < 3; {
+		switch step {
+		case 0:
+			indices, err := selectIndicesTUI("Select install targets", targetsSummary(targets), targetSelection, false)
+			if err != nil {
+				return nil, false, nil, err
+			}
+			targetSelection = selectionMap(indices)
+			selectedTargets = filterTargets(targets, indices)
+			if len(selectedTargets) == 0 {
+				return nil, false, nil, errors.New("no targets selected")
+			}
+			step++
+		case 1:
+			var err error
+			overwriteAll, err = promptOverwriteTUI()
+			if errors.Is(err, errBack) {
+				step--
+				continue
+			}
+			if err != nil {
+				return nil, false, nil, err
+			}
+			step++
+		case 2:
+			indices, err := selectIndicesStepTUI("Select skills to install", skillsSummary(skills), skillSelection, skillFiles(skills))
+			skillSelection = selectionMap(indices)
+			if errors.Is(err, errBack) {
+				step--
+				continue
+			}
+			if err != nil {
+				return nil, false, nil, err
+			}
+			selectedSkills = filterSkills(skills, indices)
+			step++
+		}
+	}
+	return selectedTargets, overwriteAll, selectedSkills, nil
+}
+
+func initialSelection(value string, count int) map[int]bool {
+	if value == "none" {
+		return make(map[int]bool)
+	}
+	return defaultSelectAll(count)
 }
 
 func defaultSelectAll(count int) map[int]bool {
@@ -443,19 +1501,37 @@ func defaultSelectAll(count int) map[int]bool {
 	return selected
 }
 
-func promptInstallFlowTUI(banner string) (bool, error) {
-	items := []string{
-		"Default install (bundled skills, copy, no project path)",
-		"Advanced (choose source, project path, install mode)",
-	}
-	idx, err := selectIndexTUI("Install mode", items, 0, banner)
-	if err != nil {
-		return false, err
+func promptInstallFlowTUI(banner string, cfg *appConfig) (bool, error) {
+	for {
+		mode := resolveInstallMode(*cfg)
+		items := []string{
+			fmt.Sprintf("Default install (bundled skills, %s, no project path)", mode),
+			"Advanced (choose source, project path, install mode)",
+			fmt.Sprintf("Change default install mode (currently %s)", mode),
+		}
+		idx, err := selectIndexTUI("Install mode", items, 0, banner)
+		if err != nil {
+			return false, err
+		}
+		if idx != 2 {
+			return idx == 1, nil
+		}
+		mode, err = promptInstallModeTUI(*cfg)
+		if err != nil {
+			return false, err
+		}
+		cfg.InstallMode = string(mode)
+		path, err := configFilePath()
+		if err != nil {
+			return false, err
+		}
+		if err := setConfigValue(path, "install-mode", string(mode)); err != nil {
+			return false, fmt.Errorf("save install mode: %w", err)
+		}
 	}
-	return idx == 1, nil
 }
 
-func promptSourceSelectionTUI(defaultRoot string, cfg appConfig) (configSelection, error) {
+func promptSourceSelectionTUI(ctx context.Context, clone cloneOptions, defaultRoot string, cfg appConfig) (configSelection, error) {
 	cwd, _ := os.Getwd()
 	defaultCfg := withDefaultConfig(cfg, defaultRoot, cwd)
 	root, err := promptSkillsRootTUI(defaultRoot, defaultCfg, cwd)
@@ -465,24 +1541,109 @@ func promptSourceSelectionTUI(defaultRoot string, cfg appConfig) (configSelectio
 	if root == "" {
 		return configSelection{}, errors.New("no skills source selected")
 	}
-	resolved, cleanup, err := resolveSkillRepoPath(root, defaultRoot, cwd)
+	skillsRoot := false
+	if root != "bundled" && root != "cwd" && installer.ExistsDir(root) && !installer.ExistsDir(filepath.Join(root, "skills")) {
+		items := []string{
+			"Use it as the skills root",
+			"Clone a GitHub repo into it",
+			"Abort",
+		}
+		idx, err := selectIndexTUI(fmt.Sprintf("%s has no skills/ folder", root), items, 0, "")
+		if err != nil {
+			return configSelection{}, err
+		}
+		switch idx {
+		case 0:
+			skillsRoot = true
+		case 1:
+			repo, err := textInputTUI("GitHub repo", fmt.Sprintf("Enter the repo URL or owner/name to clone into %s:", root), "")
+			if err != nil {
+				return configSelection{}, err
+			}
+			if err := cloneIntoDir(ctx, clone, repo, root); err != nil {
+				return configSelection{}, err
+			}
+		default:
+			return configSelection{}, fmt.Errorf("%s has no skills/ folder", root)
+		}
+	}
+	resolved, cleanup, err := resolveSkillRepoPath(ctx, clone, cfg.Repos, root, defaultRoot, cwd)
 	if err != nil {
 		return configSelection{}, err
 	}
-	return configSelection{root: resolved, cleanup: cleanup}, nil
+	return configSelection{root: resolved, cleanup: cleanup, skillsRoot: skillsRoot}, nil
+}
+
+func resolveRepoRoot(ctx context.Context, clone cloneOptions, repo string) (string, func(), error) {
+	defaultRoot, _ := detectRepoRoot("")
+	cwd, err := os.Getwd()
+	if err != nil {
+		return "", nil, fmt.Errorf("get working directory: %w", err)
+	}
+	cfg, err := loadConfig()
+	if err != nil {
+		return "", nil, err
+	}
+	if strings.TrimSpace(repo) == "" && strings.TrimSpace(cfg.SkillRepoPath) == "" {
+		if repo, err = defaultRepoName(cfg.Repos); err != nil {
+			return "", nil, err
+		}
+	}
+	if strings.TrimSpace(repo) == "" {
+		repo = withDefaultConfig(cfg, defaultRoot, cwd).SkillRepoPath
+	}
+	clone = clone.withEnvToken()
+	root, cleanup, err := resolveSkillRepoPath(ctx, clone, cfg.Repos, repo, defaultRoot, cwd)
+	return root, cleanup, clone.redactError(err)
+}
+
+func findRepo(repos []namedRepo, name string) (namedRepo, bool) {
+	name = strings.TrimSpace(name)
+	for _, repo := range repos {
+		if name != "" && repo.Name == name {
+			return repo, true
+		}
+	}
+	return namedRepo{}, false
+}
+
+func repoNames(repos []namedRepo) []string {
+	names := make([]string, 0, len(repos))
+	for _, repo := range repos {
+		names = append(names, repo.Name)
+	}
+	return names
 }
 
-func resolveSkillRepoPath(value, defaultRoot, cwd string) (string, func(), error) {
+func defaultRepoName(repos []namedRepo) (string, error) {
+	switch len(repos) {
+	case 0:
+		return "", nil
+	case 1:
+		return repos[0].Name, nil
+	default:
+		return "", fmt.Errorf("%d skill repos configured (%s); pick one with --repo-name", len(repos), strings.Join(repoNames(repos), ", "))
+	}
+}
+
+func resolveSkillRepoPath(ctx context.Context, clone cloneOptions, repos []namedRepo, value, defaultRoot, cwd string) (string, func(), error) {
+	if repo, ok := findRepo(repos, value); ok {
+		debugf("repo name %s refers to %s", repo.Name, repo.Path)
+		value = repo.Path
+	}
 	switch strings.TrimSpace(value) {
 	case "", "bundled":
 		if defaultRoot != "" {
+			debugf("using bundled skills repo %s", defaultRoot)
 			return defaultRoot, nil, nil
 		}
 		if cwd != "" {
+			debugf("no bundled skills repo; using working directory %s", cwd)
 			return cwd, nil, nil
 		}
 	case "cwd":
 		if cwd != "" {
+			debugf("using working directory %s as the skills repo", cwd)
 			return cwd, nil, nil
 		}
 	}
@@ -490,31 +1651,217 @@ func resolveSkillRepoPath(value, defaultRoot, cwd string) (string, func(), error
 		return "", nil, errors.New("empty skills repo path")
 	}
 	if installer.ExistsDir(value) {
+		debugf("using local skills repo %s", value)
 		return value, nil, nil
 	}
-	return cloneRepo(value)
+	if isSkillsArchive(value) {
+		debugf("%s is a skills archive; extracting it", value)
+		return openSkillsArchive(ctx, clone, value)
+	}
+	debugf("%s is not a local directory; fetching it as a remote repo", value)
+	if clone.latestRelease {
+		if _, ref := splitRepoRef(value); ref != "" {
+			return "", nil, fmt.Errorf("--release cannot be combined with a pinned ref (%s)", value)
+		}
+		return fetchLatestRelease(ctx, clone, value)
+	}
+	return cloneRepo(ctx, clone, value)
+}
+
+type cloneOptions struct {
+	sshKey          string
+	latestRelease   bool
+	maxSize         int64
+	token           string
+	tokenHost       string
+	refresh         bool
+	refreshOnStart  bool
+	refreshCooldown time.Duration
+	cacheTTL        time.Duration
+	noCache         bool
+}
+
+func (o cloneOptions) env() []string {
+	env := append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+	if o.sshKey != "" {
+		env = append(env, "GIT_SSH_COMMAND=ssh -i "+shellQuote(o.sshKey)+" -o IdentitiesOnly=yes")
+	}
+	return append(env, o.tokenEnv()...)
+}
+
+func checkCloneSize(ctx context.Context, clone cloneOptions, repo string) error {
+	limit := clone.maxSize
+	if limit <= 0 {
+		return nil
+	}
+	owner, name, ok := githubRepoSlug(repo)
+	if !ok {
+		return nil
+	}
+	size, err := githubRepoSize(ctx, clone, owner, name)
+	if err != nil || size <= limit {
+		return nil
+	}
+	prompt := fmt.Sprintf("%s/%s is about %s, above --max-clone-size %s. Clone anyway? [y/N]: ", owner, name, formatByteSize(size), formatByteSize(limit))
+	if isTerminal(os.Stdin) && confirm(stdinReader, prompt) {
+		return nil
+	}
+	return fmt.Errorf("%s/%s is about %s, above --max-clone-size %s; use --release to download a tarball, clone it yourself and pass the local path to --repo, or raise the limit", owner, name, formatByteSize(size), formatByteSize(limit))
+}
+
+func parseByteSize(value string) (int64, error) {
+	text := strings.ToUpper(strings.TrimSpace(value))
+	text = strings.TrimSuffix(strings.TrimSuffix(text, "B"), "I")
+	multiplier := int64(1)
+	if text != "" {
+		switch text[len(text)-1] {
+		case 'K':
+			multiplier = 1 << 10
+		case 'M':
+			multiplier = 1 << 20
+		case 'G':
+			multiplier = 1 << 30
+		}
+		if multiplier > 1 {
+			text = text[:len(text)-1]
+		}
+	}
+	n, err := strconv.ParseFloat(strings.TrimSpace(text), 64)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("%q is not a size (e.g. 500K, 200MB, 1G)", value)
+	}
+	return int64(n * float64(multiplier)), nil
+}
+
+func formatByteSize(n int64) string {
+	switch {
+	case n >= 1<<30:
+		return fmt.Sprintf("%.1f GB", float64(n)/(1<<30))
+	case n >= 1<<20:
+		return fmt.Sprintf("%.1f MB", float64(n)/(1<<20))
+	case n >= 1<<10:
+		return fmt.Sprintf("%.1f KB", float64(n)/(1<<10))
+	default:
+		return fmt.Sprintf("%d B", n)
+	}
+}
+
+func shellQuote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
 }
 
-func cloneRepo(repo string) (string, func(), error) {
+func cloneRepo(ctx context.Context, clone cloneOptions, repo string) (string, func(), error) {
+	repo, ref := splitRepoRef(repo)
 	repoURL := normalizeRepoURL(repo)
+	clone = clone.forRepo(repoURL)
+	if err := checkCloneSize(ctx, clone, repo); err != nil {
+		return "", nil, err
+	}
+	if isCommitRef(ref) {
+		debugf("pinning %s to commit %s", repoURL, ref)
+		return cloneAtCommit(ctx, clone, repoURL, ref)
+	}
+	cacheKey := repoURL
+	if ref != "" {
+		debugf("pinning %s to %s", repoURL, ref)
+		cacheKey += "#" + ref
+	}
+	if dir, ok := usableCacheDir(clone, cacheKey); ok {
+		if err := cachedClone(ctx, clone, repoURL, ref, dir); err != nil {
+			return "", nil, fmt.Errorf("clone %s: %w", repoURL, err)
+		}
+		return dir, nil, nil
+	}
 	tempDir, err := os.MkdirTemp("", "askill-repo-*")
 	if err != nil {
 		return "", nil, err
 	}
-	cmd := exec.Command("git", "clone", "--depth", "1", repoURL, tempDir)
-	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-	if err := cmd.Run(); err != nil {
-		_ = os.RemoveAll(tempDir)
+	cleanup := registerCleanup(func() { _ = os.RemoveAll(tempDir) })
+	if err := runGit(ctx, clone, cloneArgs(repoURL, ref, tempDir)...); err != nil {
+		cleanup()
 		return "", nil, fmt.Errorf("clone %s: %w", repoURL, err)
 	}
-	cleanup := func() { _ = os.RemoveAll(tempDir) }
 	return tempDir, cleanup, nil
 }
 
+func cloneIntoDir(ctx context.Context, clone cloneOptions, repo, dir string) error {
+	repo, ref := splitRepoRef(strings.TrimSpace(repo))
+	if repo == "" {
+		return errors.New("no repo to clone")
+	}
+	repoURL := normalizeRepoURL(repo)
+	clone = clone.forRepo(repoURL)
+	if installer.ExistsDir(filepath.Join(dir, ".git")) {
+		return fmt.Errorf("clone %s into %s: it is already a git repo", repoURL, dir)
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+	if len(entries) == 0 {
+		if err := runGit(ctx, clone, cloneArgs(repoURL, ref, dir)...); err != nil {
+			return fmt.Errorf("clone %s: %w", repoURL, err)
+		}
+		return nil
+	}
+	fetch := []string{"-C", dir, "fetch", "-q", "--depth", "1", "origin"}
+	if ref != "" {
+		fetch = append(fetch, ref)
+	}
+	for _, args := range [][]string{
+		{"-C", dir, "init", "-q"},
+		{"-C", dir, "remote", "add", "origin", repoURL},
+		fetch,
+		{"-C", dir, "checkout", "-q", "--detach", "FETCH_HEAD"},
+	} {
+		if err := runGit(ctx, clone, args...); err != nil {
+			_ = os.RemoveAll(filepath.Join(dir, ".git"))
+			return fmt.Errorf("clone %s into %s: %w", repoURL, dir, err)
+		}
+	}
+	return nil
+}
+
+func withTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
+func timeoutError(err error, timeout time.Duration) error {
+	if timeout > 0 && errors.Is(err, context.DeadlineExceeded) {
+		return fmt.Errorf("timed out after %s: %w", timeout, err)
+	}
+	return err
+}
+
+func cacheTTL(cfg appConfig) (time.Duration, error) {
+	value := strings.TrimSpace(cfg.CacheTTL)
+	if value == "" {
+		return defaultCacheTTL, nil
+	}
+	ttl, err := time.ParseDuration(value)
+	if err != nil || ttl < 0 {
+		return 0, fmt.Errorf("invalid cache-ttl %q: want a duration such as 24h, or 0 to never expire", value)
+	}
+	return ttl, nil
+}
+
+func refreshCooldown(cfg appConfig) (time.Duration, error) {
+	value := strings.TrimSpace(cfg.RefreshCooldown)
+	if value == "" {
+		return defaultRefreshCooldown, nil
+	}
+	cooldown, err := time.ParseDuration(value)
+	if err != nil || cooldown < 0 {
+		return 0, fmt.Errorf("invalid refresh-cooldown %q: want a duration such as 15m or 1h", value)
+	}
+	return cooldown, nil
+}
+
 func normalizeRepoURL(repo string) string {
-	if strings.HasPrefix(repo, "http://") || strings.HasPrefix(repo, "https://") || strings.HasPrefix(repo, "git@") {
+	if strings.HasPrefix(repo, "http://") || strings.HasPrefix(repo, "https://") || strings.HasPrefix(repo, "git@") || strings.HasPrefix(repo, "ssh://") || strings.HasPrefix(repo, "file://") {
 		return repo
 	}
 	if strings.HasPrefix(repo, "github.com/") {
@@ -527,39 +1874,64 @@ func normalizeRepoURL(repo string) string {
 }
 
 func loadConfig() (appConfig, error) {
-	configDir, err := os.UserConfigDir()
+	path, err := configFilePath()
 	if err != nil {
 		return appConfig{}, err
 	}
-	path := filepath.Join(configDir, "askill", "config.toml")
 	if _, err := os.Stat(path); err != nil {
 		if errors.Is(err, os.ErrNotExist) {
+			debugf("config %s not found; using defaults", path)
 			return appConfig{}, nil
 		}
 		return appConfig{}, err
 	}
 	var cfg appConfig
-	if _, err := toml.DecodeFile(path, &cfg); err != nil {
+	md, err := toml.DecodeFile(path, &cfg)
+	if err != nil {
 		return appConfig{}, err
 	}
+	for _, key := range md.Undecoded() {
+		fmt.Fprintf(os.Stderr, "warning: %s: %s\n", path, unknownConfigKeyWarning(key.String()))
+	}
+	if err := validateConfig(cfg); err != nil {
+		return appConfig{}, fmt.Errorf("config %s: %w", path, err)
+	}
+	debugf("loaded config %s", path)
 	return cfg, nil
 }
 
 func runConfigCommand(args []string, cmdName string) error {
+	if len(args) > 0 {
+		switch args[0] {
+		case "get":
+			return runConfigGet(args[1:], cmdName)
+		case "set":
+			return runConfigSet(args[1:], cmdName)
+		}
+	}
 	fs := flag.NewFlagSet(cmdName+" config", flag.ContinueOnError)
 	fs.SetOutput(os.Stderr)
 	var edit bool
 	var init bool
+	var effective bool
+	var output string
 	fs.BoolVar(&edit, "edit", false, "edit config in $EDITOR/$VISUAL")
 	fs.BoolVar(&edit, "e", false, "alias for --edit")
 	fs.BoolVar(&init, "init", false, "create config with defaults if missing")
+	fs.BoolVar(&effective, "effective", false, "show the config with defaults applied and the resolved repo, project, and mode")
+	fs.StringVar(&output, "output", "text", "output format: text or json")
 	fs.Usage = func() {
 		out := fs.Output()
-		fmt.Fprintf(out, "Usage: %s config [--init] [-e|--edit]\n\n", cmdName)
+		fmt.Fprintf(out, "Usage: %s config [--init] [-e|--edit] [--effective] [--output text|json]\n", cmdName)
+		fmt.Fprintf(out, "       %s config get KEY\n", cmdName)
+		fmt.Fprintf(out, "       %s config set KEY VALUE\n\n", cmdName)
+		fmt.Fprintf(out, "Keys for get/set: %s\n\n", configKeyNames())
 		fmt.Fprintln(out, "Options:")
 		tw := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
 		fmt.Fprintln(tw, "  --init\tCreate config file with defaults")
 		fmt.Fprintln(tw, "  -e, --edit\tEdit config in $EDITOR/$VISUAL")
+		fmt.Fprintln(tw, "  --effective\tShow the config with defaults applied and the resolved repo, project, and mode")
+		fmt.Fprintln(tw, "  --output\tOutput format: text or json")
 		fmt.Fprintln(tw, "  -h, --help\tShow help")
 		_ = tw.Flush()
 	}
@@ -570,11 +1942,19 @@ func runConfigCommand(args []string, cmdName string) error {
 		return err
 	}
 
+	if output != "text" && output != "json" {
+		return fmt.Errorf("invalid --output %q: want text or json", output)
+	}
+
 	configPath, err := configFilePath()
 	if err != nil {
 		return err
 	}
 
+	if effective {
+		return printEffectiveConfig(configPath, output)
+	}
+
 	if init {
 		if err := ensureConfigFile(configPath); err != nil {
 			return err
@@ -599,6 +1979,9 @@ func runConfigCommand(args []string, cmdName string) error {
 	if err != nil {
 		return err
 	}
+	if output == "json" {
+		return printJSON(cfg)
+	}
 	if err := printConfig(cfg); err != nil {
 		return err
 	}
@@ -606,7 +1989,66 @@ func runConfigCommand(args []string, cmdName string) error {
 	return nil
 }
 
+type effectiveConfig struct {
+	ConfigPath  string         `json:"config_path"`
+	Config      appConfig      `json:"config"`
+	RepoRoot    string         `json:"repo_root"`
+	ProjectPath string         `json:"project_path"`
+	InstallMode installer.Mode `json:"install_mode"`
+}
+
+func printEffectiveConfig(configPath, output string) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("get working directory: %w", err)
+	}
+	defaultRoot, _ := detectRepoRoot("")
+	defaultCfg := withDefaultConfig(cfg, defaultRoot, cwd)
+	root, cleanup, err := resolveRepoRoot(context.Background(), cloneOptions{}, "")
+	if err != nil {
+		return err
+	}
+	if cleanup != nil {
+		defer cleanup()
+	}
+	eff := effectiveConfig{
+		ConfigPath:  configPath,
+		Config:      defaultCfg,
+		RepoRoot:    root,
+		ProjectPath: resolveProjectPath(defaultCfg, cwd),
+		InstallMode: resolveInstallMode(defaultCfg),
+	}
+	if output == "json" {
+		return printJSON(eff)
+	}
+	if err := printConfig(eff.Config); err != nil {
+		return err
+	}
+	fmt.Println()
+	fmt.Printf("Config path: %s\n", eff.ConfigPath)
+	fmt.Printf("Repo root: %s\n", eff.RepoRoot)
+	fmt.Printf("Project path: %s\n", eff.ProjectPath)
+	fmt.Printf("Install mode: %s\n", eff.InstallMode)
+	return nil
+}
+
+func printJSON(value any) error {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(value)
+}
+
 func configFilePath() (string, error) {
+	if configPathOverride != "" {
+		return configPathOverride, nil
+	}
+	if path := strings.TrimSpace(os.Getenv("ASKILL_CONFIG")); path != "" {
+		return path, nil
+	}
 	configDir, err := os.UserConfigDir()
 	if err != nil {
 		return "", err
@@ -623,14 +2065,61 @@ func ensureConfigFile(path string) error {
 	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
 		return err
 	}
-	defaultRoot, _ := detectRepoRoot()
+	data, err := defaultConfigData()
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+func defaultConfigData() ([]byte, error) {
+	defaultRoot, _ := detectRepoRoot("")
 	cwd, _ := os.Getwd()
 	defaults := withDefaultConfig(appConfig{}, defaultRoot, cwd)
 	var b strings.Builder
 	if err := toml.NewEncoder(&b).Encode(defaults); err != nil {
+		return nil, err
+	}
+	return []byte(b.String()), nil
+}
+
+func setConfigValue(path, key, value string) error {
+	data, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
 		return err
 	}
-	return os.WriteFile(path, []byte(b.String()), 0o644)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, configWithValue(data, key, value), 0o644)
+}
+
+func configWithValue(data []byte, key, value string) []byte {
+	line := fmt.Sprintf("%s = %s", key, strconv.Quote(value))
+	lines := strings.Split(strings.TrimRight(string(data), "\n"), "\n")
+	if len(data) == 0 {
+		lines = nil
+	}
+	insertAt := len(lines)
+	for i, existing := range lines {
+		trimmed := strings.TrimSpace(existing)
+		if strings.HasPrefix(trimmed, "[") {
+			insertAt = i
+			break
+		}
+		if name, _, ok := strings.Cut(trimmed, "="); ok && strings.TrimSpace(name) == key {
+			lines[i] = line
+			insertAt = -1
+			break
+		}
+	}
+	for insertAt > 0 && strings.TrimSpace(lines[insertAt-1]) == "" {
+		insertAt--
+	}
+	if insertAt >= 0 {
+		lines = append(lines[:insertAt], append([]string{line}, lines[insertAt:]...)...)
+	}
+	return []byte(strings.Join(lines, "\n") + "\n")
 }
 
 func editConfigFile(path string) error {
@@ -669,6 +2158,9 @@ func withDefaultConfig(cfg appConfig, defaultRoot, cwd string) appConfig {
 	if strings.TrimSpace(cfg.InstallMode) == "" {
 		cfg.InstallMode = "copy"
 	}
+	if strings.TrimSpace(cfg.DefaultSelection) == "" {
+		cfg.DefaultSelection = "all"
+	}
 	if cfg.ProjectChoice != "custom" {
 		cfg.ProjectPath = strings.TrimSpace(cfg.ProjectPath)
 	}
@@ -687,12 +2179,24 @@ func resolveProjectPath(cfg appConfig, cwd string) string {
 }
 
 func resolveInstallMode(cfg appConfig) installer.Mode {
-	if strings.EqualFold(cfg.InstallMode, string(installer.ModeCopy)) {
-		return installer.ModeCopy
+	for _, mode := range []installer.Mode{installer.ModeCopy, installer.ModeSymlink, installer.ModeHardlink} {
+		if strings.EqualFold(cfg.InstallMode, string(mode)) {
+			return mode
+		}
 	}
 	return installer.ModeCopy
 }
 
+func countTrue(values ...bool) int {
+	n := 0
+	for _, value := range values {
+		if value {
+			n++
+		}
+	}
+	return n
+}
+
 type brewInfo struct {
 	Formulae []struct {
 		Name     string `json:"name"`
@@ -706,21 +2210,22 @@ func maybeUpgradeBanner(current string) string {
 	if current == "" {
 		return ""
 	}
-	latest, err := brewLivecheckVersion()
-	if err != nil || latest == "" {
-		latest, err = brewStableVersion()
-	}
-	if err != nil || latest == "" {
+	latest, source := latestVersion()
+	if latest == "" {
 		return ""
 	}
 	if compareVersions(current, latest) >= 0 {
 		return ""
 	}
+	if source == updateSourceGitHub {
+		return fmt.Sprintf("A newer askill version (%s) is available at https://github.com/%s/%s/releases/latest", latest, releaseOwner, releaseRepo)
+	}
 	return fmt.Sprintf("A newer askill version (%s) is available. Run: brew update && brew upgrade askill", latest)
 }
 
-func brewStableVersion() (string, error) {
-	cmd := exec.Command("brew", "info", "--json=v2", "askill")
+func brewStableVersion(ctx context.Context) (string, error) {
+	cmd := exec.CommandContext(ctx, "brew", "info", "--json=v2", "askill")
+	cmd.WaitDelay = time.Second
 	output, err := cmd.Output()
 	if err != nil {
 		return "", err
@@ -737,8 +2242,9 @@ func brewStableVersion() (string, error) {
 	return "", errors.New("askill formula not found")
 }
 
-func brewLivecheckVersion() (string, error) {
-	cmd := exec.Command("brew", "livecheck", "askill")
+func brewLivecheckVersion(ctx context.Context) (string, error) {
+	cmd := exec.CommandContext(ctx, "brew", "livecheck", "askill")
+	cmd.WaitDelay = time.Second
 	output, err := cmd.CombinedOutput()
 	latest := parseLivecheckOutput(string(output))
 	if err != nil && latest == "" {
@@ -769,10 +2275,10 @@ func parseLivecheckOutput(output string) string {
 }
 
 func compareVersions(a, b string) int {
-	a = strings.TrimPrefix(strings.TrimSpace(a), "v")
-	b = strings.TrimPrefix(strings.TrimSpace(b), "v")
-	aParts := strings.Split(a, ".")
-	bParts := strings.Split(b, ".")
+	aCore, aPre := splitVersion(a)
+	bCore, bPre := splitVersion(b)
+	aParts := strings.Split(aCore, ".")
+	bParts := strings.Split(bCore, ".")
 	maxLen := len(aParts)
 	if len(bParts) > maxLen {
 		maxLen = len(bParts)
@@ -792,12 +2298,61 @@ func compareVersions(a, b string) int {
 			return 1
 		}
 	}
-	return 0
+	return comparePrerelease(aPre, bPre)
+}
+
+func splitVersion(version string) (string, string) {
+	version = strings.TrimPrefix(strings.TrimSpace(version), "v")
+	version, _, _ = strings.Cut(version, "+")
+	core, pre, _ := strings.Cut(version, "-")
+	return core, pre
+}
+
+func comparePrerelease(a, b string) int {
+	switch {
+	case a == b:
+		return 0
+	case a == "":
+		return 1
+	case b == "":
+		return -1
+	}
+	aIDs := strings.Split(a, ".")
+	bIDs := strings.Split(b, ".")
+	for i := 0; i < len(aIDs) && i < len(bIDs); i++ {
+		aNum, aErr := strconv.Atoi(aIDs[i])
+		bNum, bErr := strconv.Atoi(bIDs[i])
+		switch {
+		case aErr == nil && bErr == nil:
+			if aNum != bNum {
+				return cmpInt(aNum, bNum)
+			}
+		case aErr == nil:
+			return -1
+		case bErr == nil:
+			return 1
+		default:
+			if c := strings.Compare(aIDs[i], bIDs[i]); c != 0 {
+				return c
+			}
+		}
+	}
+	return cmpInt(len(aIDs), len(bIDs))
+}
+
+func cmpInt(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
 }
 
 func parseVersionPart(part string) int {
 	part = strings.TrimSpace(part)
-	part = strings.TrimLeft(part, "v")
 	n, err := strconv.Atoi(part)
 	if err != nil {
 		return 0
@@ -0,0 +1,238 @@
+package cli
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"agent-skills/internal/installer"
+)
+
+func TestCompareVersions(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"1.2.0", "1.2.0", 0},
+		{"1.2.0", "1.2.0-rc1", 1},
+		{"1.2.0-rc1", "1.2.0", -1},
+		{"1.2.0-rc.2", "1.2.0-rc.10", -1},
+		{"1.2.0-alpha", "1.2.0-beta", -1},
+		{"1.2.0-1", "1.2.0-alpha", -1},
+		{"1.2.0-rc", "1.2.0-rc.1", -1},
+		{"1.2.0-rc1", "1.1.9", 1},
+		{"1.10.0", "1.9.0", 1},
+		{"v2", "1.99", 1},
+		{"v1.2", "1.2.0", 0},
+		{"1.2", "1.2.1", -1},
+		{"1.2.0+build.5", "1.2.0", 0},
+		{"1.2.0-rc1+build", "1.2.0-rc1", 0},
+	}
+	for _, tt := range tests {
+		if got := compareVersions(tt.a, tt.b); got != tt.want {
+			t.Errorf("compareVersions(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestInstallSingleFileSkills(t *testing.T) {
+	repo := t.TempDir()
+	writeTestFile(t, filepath.Join(repo, "skills", "review.md"), "---\nname: review\n---\nReview code.\n")
+	writeTestFile(t, filepath.Join(repo, "skills", "notes.md"), "Just notes.\n")
+	home := t.TempDir()
+	target := filepath.Join(home, ".claude", "skills")
+	if err := os.MkdirAll(target, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	args := []string{"--repo", repo, "--target", "claude-global", "--copy", "--yes"}
+
+	if _, err := runAskill(t, home, args...); err == nil {
+		t.Fatal("default discovery picked up single-file skills")
+	}
+	args = append(args, "--single-file-skills")
+	if _, err := runAskill(t, home, args...); err != nil {
+		t.Fatal(err)
+	}
+	info, err := os.Stat(filepath.Join(target, "review.md"))
+	if err != nil || !info.Mode().IsRegular() {
+		t.Fatalf("review.md not installed as a file: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(target, "notes.md")); err == nil {
+		t.Error("markdown without frontmatter was installed")
+	}
+	if meta, err := installer.ReadMeta(filepath.Join(target, "review.md")); err != nil || meta.Layout != installer.LayoutFlat {
+		t.Errorf("meta = %+v, %v; want a flat layout", meta, err)
+	}
+	if out, err := runAskill(t, home, args...); err != nil || !strings.HasPrefix(out, "Nothing to do") {
+		t.Errorf("second run = %q, %v; want nothing to do", out, err)
+	}
+}
+
+func TestSanitizeName(t *testing.T) {
+	tests := map[string]string{
+		"Code Review 🔍":     "Code-Review",
+		"  padded  name  ":  "padded-name",
+		"a - b":             "a-b",
+		"résumé writer":     "résumé-writer",
+		"日本語 スキル":           "日本語-スキル",
+		"v1.2_notes":        "v1.2_notes",
+		"🔍🚀":                "skill",
+		"Code Review 🔍.md":  "Code-Review.md",
+		"tools-lint (beta)": "tools-lint-beta",
+	}
+	for name, want := range tests {
+		if got := sanitizeName(name); got != want {
+			t.Errorf("sanitizeName(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestInstallSanitizesUnicodeNames(t *testing.T) {
+	const name = "Code Review 🔍"
+	repo := t.TempDir()
+	src := filepath.Join(repo, "skills", name)
+	writeTestFile(t, filepath.Join(src, "SKILL.md"), "---\ndescription: Reviews code\n---\n")
+	home := t.TempDir()
+	target := filepath.Join(home, ".claude", "skills")
+	if err := os.MkdirAll(target, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	args := []string{"--repo", repo, "--target", "claude-global", "--copy", "--yes"}
+
+	if _, err := runAskill(t, home, args...); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(target, name, "SKILL.md")); err != nil {
+		t.Errorf("install without --sanitize-names did not keep the name: %v", err)
+	}
+
+	if _, err := runAskill(t, home, append(args, "--sanitize-names")...); err != nil {
+		t.Fatal(err)
+	}
+	dest := filepath.Join(target, "Code-Review")
+	if _, err := os.Stat(filepath.Join(dest, "SKILL.md")); err != nil {
+		t.Fatalf("sanitized install missing: %v", err)
+	}
+	if meta, err := installer.ReadMeta(dest); err != nil || meta.OriginalName != name || meta.Skill != name {
+		t.Errorf("meta = %+v, %v; want original name %q", meta, err, name)
+	}
+	if _, err := os.Stat(filepath.Join(src, "SKILL.md")); err != nil {
+		t.Errorf("source was touched: %v", err)
+	}
+}
+
+func TestCloneIntoDir(t *testing.T) {
+	remote := testRemote(t, "cloned")
+
+	empty := t.TempDir()
+	if err := cloneIntoDir(context.Background(), cloneOptions{}, remote, empty); err != nil {
+		t.Fatal(err)
+	}
+	if !installer.ExistsDir(filepath.Join(empty, "skills", "cloned")) {
+		t.Fatal("empty dir did not receive the clone")
+	}
+
+	existing := t.TempDir()
+	notes := filepath.Join(existing, "notes.txt")
+	writeTestFile(t, notes, "keep")
+	if err := cloneIntoDir(context.Background(), cloneOptions{}, remote, existing); err != nil {
+		t.Fatal(err)
+	}
+	if !installer.ExistsDir(filepath.Join(existing, "skills", "cloned")) {
+		t.Fatal("non-empty dir did not receive the clone")
+	}
+	if data, err := os.ReadFile(notes); err != nil || string(data) != "keep" {
+		t.Fatalf("existing file = %q, %v; want it untouched", data, err)
+	}
+
+	if err := cloneIntoDir(context.Background(), cloneOptions{}, remote, existing); err == nil || !strings.Contains(err.Error(), "already a git repo") {
+		t.Fatalf("cloning into a git repo: err = %v", err)
+	}
+
+	conflict := t.TempDir()
+	skill := filepath.Join(conflict, "skills", "cloned", "SKILL.md")
+	writeTestFile(t, skill, "mine")
+	if err := cloneIntoDir(context.Background(), cloneOptions{}, remote, conflict); err == nil {
+		t.Fatal("clone over a conflicting file succeeded")
+	}
+	if data, _ := os.ReadFile(skill); string(data) != "mine" {
+		t.Fatalf("conflicting file = %q, want it untouched", data)
+	}
+	if installer.ExistsDir(filepath.Join(conflict, ".git")) {
+		t.Fatal("failed clone left a .git dir behind")
+	}
+}
+
+func TestNoOverwritePromptWithOnlyMissing(t *testing.T) {
+	repo := testSkillsRepo(t, "managed", "foreign", "new")
+	home := t.TempDir()
+	skillsDir := filepath.Join(home, ".claude", "skills")
+	if err := os.MkdirAll(skillsDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	args := []string{"--repo", repo, "--target", "claude-global", "--copy", "--only-missing", "--no-overwrite-prompt"}
+	if _, err := runAskill(t, home, append(args, "--skills", "managed")...); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := runAskill(t, home, append(args, "--skills", "managed,new")...); err != nil {
+		t.Fatalf("managed entry failed --no-overwrite-prompt --only-missing: %v", err)
+	}
+	if err := installer.RemoveSkill(filepath.Join(skillsDir, "new")); err != nil {
+		t.Fatal(err)
+	}
+	foreign := filepath.Join(skillsDir, "foreign", "SKILL.md")
+	writeTestFile(t, foreign, "hand written\n")
+
+	_, err := runAskill(t, home, append(args, "--skills", "managed,foreign,new")...)
+	if err == nil || !strings.Contains(err.Error(), "foreign in") || strings.Contains(err.Error(), "managed in") {
+		t.Fatalf("err = %v, want only the unmanaged entry reported", err)
+	}
+	if data, _ := os.ReadFile(foreign); string(data) != "hand written\n" {
+		t.Fatalf("unmanaged entry = %q, want it untouched", data)
+	}
+	if _, err := os.Lstat(filepath.Join(skillsDir, "new")); err == nil {
+		t.Fatal("new skill was installed although the run failed")
+	}
+}
+
+func TestFailedVerificationRestoresPreviousInstall(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("needs symlinks")
+	}
+	repo := testSkillsRepo(t, "review")
+	home := t.TempDir()
+	skillsDir := filepath.Join(home, ".claude", "skills")
+	if err := os.MkdirAll(skillsDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	args := []string{"--repo", repo, "--target", "claude-global", "--copy", "--yes"}
+	if _, err := runAskill(t, home, args...); err != nil {
+		t.Fatal(err)
+	}
+	installed := filepath.Join(skillsDir, "review", "SKILL.md")
+	previous, err := os.ReadFile(installed)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	source := filepath.Join(repo, "skills", "review", "SKILL.md")
+	writeTestFile(t, filepath.Join(repo, "shared.md"), "---\nname: review\n---\nshared\n")
+	if err := os.Remove(source); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(filepath.Join("..", "..", "shared.md"), source); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := runAskill(t, home, args...); err == nil || !strings.Contains(err.Error(), "not reachable") {
+		t.Fatalf("err = %v, want a verification failure", err)
+	}
+	if data, err := os.ReadFile(installed); err != nil || string(data) != string(previous) {
+		t.Fatalf("installed SKILL.md = %q (%v), want the previous install back", data, err)
+	}
+	if !installer.IsManagedSkill(filepath.Join(skillsDir, "review")) {
+		t.Fatal("previous meta was not restored")
+	}
+}
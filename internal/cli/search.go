@@ -0,0 +1,179 @@
+package cli
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"agent-skills/internal/installer"
+)
+
+const searchContextRunes = 30
+
+type searchResult struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Path        string   `json:"path"`
+	Tags        []string `json:"tags,omitempty"`
+	Score       int      `json:"score"`
+	Field       string   `json:"field"`
+	Context     string   `json:"context"`
+}
+
+func runSearchCommand(args []string, cmdName string) error {
+	fs := flag.NewFlagSet(cmdName+" search", flag.ContinueOnError)
+	fs.SetOutput(os.Stderr)
+	var repoRoot string
+	var limit int
+	var jsonOutput bool
+	fs.StringVar(&repoRoot, "repo", "", "path to skills repo, a .zip of one, or a git URL")
+	fs.StringVar(&repoRoot, "r", "", "alias for --repo")
+	fs.IntVar(&limit, "limit", 0, "print at most this many results (0 means all)")
+	fs.BoolVar(&jsonOutput, "json", false, "print a JSON array instead of a table")
+	fs.Usage = func() {
+		out := fs.Output()
+		fmt.Fprintf(out, "Usage: %s search [options] QUERY\n\n", cmdName)
+		fmt.Fprintln(out, "Rank skills by how well their name, aliases, tags, or description match QUERY.")
+		fmt.Fprintln(out)
+		fmt.Fprintln(out, "Options:")
+		tw := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(tw, "  -r, --repo\tPath to skills repo, a .zip of one, or a git URL (defaults to config)")
+		fmt.Fprintln(tw, "  --limit\tPrint at most this many results (0 means all)")
+		fmt.Fprintln(tw, "  --json\tPrint a JSON array instead of a table")
+		fmt.Fprintln(tw, "  -h, --help\tShow help")
+		_ = tw.Flush()
+	}
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+	query := strings.TrimSpace(strings.Join(fs.Args(), " "))
+	if query == "" {
+		fs.Usage()
+		return errors.New("search needs a query")
+	}
+	if limit < 0 {
+		return fmt.Errorf("invalid --limit %d: must be 0 or more", limit)
+	}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	skills, cleanup, err := listSkills(repoRoot, cfg)
+	if err != nil {
+		return err
+	}
+	if cleanup != nil {
+		defer cleanup()
+	}
+	results := searchSkills(skills, query)
+	if limit > 0 && len(results) > limit {
+		results = results[:limit]
+	}
+
+	if jsonOutput {
+		return printJSON(results)
+	}
+	if len(results) == 0 {
+		fmt.Printf("No skills match %q\n", query)
+		return nil
+	}
+	tw := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "NAME\tMATCH\tCONTEXT")
+	for _, result := range results {
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", result.Name, result.Field, result.Context)
+	}
+	return tw.Flush()
+}
+
+func searchSkills(skills []installer.Skill, query string) []searchResult {
+	results := []searchResult{}
+	for _, skill := range skills {
+		if result, ok := scoreSkill(skill, query); ok {
+			results = append(results, result)
+		}
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		return strings.ToLower(results[i].Name) < strings.ToLower(results[j].Name)
+	})
+	return results
+}
+
+func scoreSkill(skill installer.Skill, query string) (searchResult, bool) {
+	result := searchResult{Name: skill.Name, Description: skill.Description, Path: skill.Path, Tags: skill.Tags}
+	consider := func(score int, field, context string) {
+		if score > result.Score {
+			result.Score, result.Field, result.Context = score, field, context
+		}
+	}
+	q := strings.ToLower(query)
+	name := strings.ToLower(skill.Name)
+	switch {
+	case name == q:
+		consider(100, "name", skill.Name)
+	case strings.HasPrefix(name, q):
+		consider(80, "name", skill.Name)
+	case strings.Contains(name, q):
+		consider(60, "name", skill.Name)
+	case fuzzyMatch(skill.Name, query):
+		consider(20, "name", skill.Name)
+	}
+	for _, alias := range skill.Aliases {
+		switch {
+		case strings.EqualFold(alias, query):
+			consider(70, "alias", alias)
+		case strings.Contains(strings.ToLower(alias), q):
+			consider(50, "alias", alias)
+		}
+	}
+	for _, tag := range skill.Tags {
+		switch {
+		case strings.EqualFold(tag, query):
+			consider(50, "tag", tag)
+		case strings.Contains(strings.ToLower(tag), q):
+			consider(40, "tag", tag)
+		}
+	}
+	if strings.EqualFold(skill.Category, query) {
+		consider(40, "category", skill.Category)
+	}
+	if snippet, ok := matchContext(skill.Description, query); ok {
+		consider(30, "description", snippet)
+	}
+	return result, result.Score > 0
+}
+
+func matchContext(text, query string) (string, bool) {
+	runes := []rune(text)
+	lower := []rune(strings.ToLower(text))
+	q := []rune(strings.ToLower(query))
+	at := -1
+	for i := 0; i+len(q) <= len(lower); i++ {
+		if string(lower[i:i+len(q)]) == string(q) {
+			at = i
+			break
+		}
+	}
+	if at < 0 {
+		return "", false
+	}
+	start, end := at-searchContextRunes, at+len(q)+searchContextRunes
+	prefix, suffix := "...", "..."
+	if start <= 0 {
+		start, prefix = 0, ""
+	}
+	if end >= len(runes) {
+		end, suffix = len(runes), ""
+	}
+	return prefix + string(runes[start:end]) + suffix, true
+}
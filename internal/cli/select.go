@@ -0,0 +1,265 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"agent-skills/internal/installer"
+)
+
+type skillDecision struct {
+	skill    installer.Skill
+	included bool
+	reason   string
+}
+
+func newSkillDecisions(skills []installer.Skill) []skillDecision {
+	decisions := make([]skillDecision, 0, len(skills))
+	for _, skill := range skills {
+		decisions = append(decisions, skillDecision{skill: skill, included: true, reason: "discovered in repo"})
+	}
+	return decisions
+}
+
+func excludeSkills(decisions []skillDecision, reason string, exclude func(installer.Skill) bool) {
+	for i := range decisions {
+		if decisions[i].included && exclude(decisions[i].skill) {
+			decisions[i].included = false
+			decisions[i].reason = reason
+		}
+	}
+}
+
+func includedSkills(decisions []skillDecision) []installer.Skill {
+	var skills []installer.Skill
+	for _, decision := range decisions {
+		if decision.included {
+			skills = append(skills, decision.skill)
+		}
+	}
+	return skills
+}
+
+func printDecisions(decisions []skillDecision) error {
+	sorted := append([]skillDecision(nil), decisions...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].skill.Name < sorted[j].skill.Name })
+	tw := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	for _, decision := range sorted {
+		status := "excluded"
+		if decision.included {
+			status = "included"
+		}
+		fmt.Fprintf(tw, "%s\t%s: %s\n", decision.skill.Name, status, decision.reason)
+	}
+	return tw.Flush()
+}
+
+const (
+	installOrderAlpha    = "alpha"
+	installOrderPriority = "priority"
+	installOrderDeps     = "deps"
+)
+
+func validateInstallOrder(order string) error {
+	switch order {
+	case installOrderAlpha, installOrderPriority, installOrderDeps:
+		return nil
+	default:
+		return fmt.Errorf("invalid --install-order %q: want alpha, priority, or deps", order)
+	}
+}
+
+func orderSkills(skills []installer.Skill, order string, report *reporter) ([]installer.Skill, error) {
+	ordered := append([]installer.Skill(nil), skills...)
+	sort.SliceStable(ordered, func(i, j int) bool { return ordered[i].Name < ordered[j].Name })
+	switch order {
+	case installOrderPriority:
+		sort.SliceStable(ordered, func(i, j int) bool { return ordered[i].Priority > ordered[j].Priority })
+	case installOrderDeps:
+		return dependencyOrder(ordered, report)
+	}
+	return ordered, nil
+}
+
+func dependencyOrder(skills []installer.Skill, report *reporter) ([]installer.Skill, error) {
+	byName := make(map[string]installer.Skill, len(skills))
+	for _, skill := range skills {
+		byName[skill.Name] = skill
+	}
+	const (
+		unvisited = iota
+		visiting
+		done
+	)
+	state := make(map[string]int, len(skills))
+	ordered := make([]installer.Skill, 0, len(skills))
+	var visit func(skill installer.Skill, path []string) error
+	visit = func(skill installer.Skill, path []string) error {
+		switch state[skill.Name] {
+		case done:
+			return nil
+		case visiting:
+			return fmt.Errorf("dependency cycle: %s", strings.Join(append(path, skill.Name), " -> "))
+		}
+		state[skill.Name] = visiting
+		for _, dep := range skill.DependsOn {
+			depSkill, ok := byName[dep]
+			if !ok {
+				report.warnf("skill %s depends on %s, which is not selected", skill.Name, dep)
+				continue
+			}
+			if err := visit(depSkill, append(path, skill.Name)); err != nil {
+				return err
+			}
+		}
+		state[skill.Name] = done
+		ordered = append(ordered, skill)
+		return nil
+	}
+	for _, skill := range skills {
+		if err := visit(skill, nil); err != nil {
+			return nil, err
+		}
+	}
+	return ordered, nil
+}
+
+func skillMatches(skill installer.Skill, name string) bool {
+	if strings.EqualFold(skill.Name, name) || strings.EqualFold(filepath.Base(skill.Path), name) || strings.EqualFold(installer.InstallName(skill), name) {
+		return true
+	}
+	for _, alias := range skill.Aliases {
+		if strings.EqualFold(alias, name) {
+			return true
+		}
+	}
+	return false
+}
+
+func skillMatchesText(skill installer.Skill, query string) bool {
+	query = strings.ToLower(query)
+	for _, field := range append([]string{skill.Name, skill.Description}, skill.Tags...) {
+		if strings.Contains(strings.ToLower(field), query) {
+			return true
+		}
+	}
+	return false
+}
+
+func hasTag(skill installer.Skill, tag string) bool {
+	for _, t := range skill.Tags {
+		if strings.EqualFold(t, tag) {
+			return true
+		}
+	}
+	return false
+}
+
+func filterByField(decisions []skillDecision, flagName, value string, keep func(installer.Skill) bool) error {
+	matched := false
+	for _, decision := range decisions {
+		if decision.included && keep(decision.skill) {
+			matched = true
+			break
+		}
+	}
+	if !matched {
+		return fmt.Errorf("%s %q matched no skills", flagName, value)
+	}
+	excludeSkills(decisions, fmt.Sprintf("does not match %s %q", flagName, value), func(skill installer.Skill) bool { return !keep(skill) })
+	return nil
+}
+
+func warnAliasCollisions(skills []installer.Skill, report *reporter) {
+	owners := make(map[string]string, len(skills))
+	for _, skill := range skills {
+		owners[strings.ToLower(skill.Name)] = skill.Name
+	}
+	for _, skill := range skills {
+		for _, alias := range skill.Aliases {
+			key := strings.ToLower(alias)
+			if owner, ok := owners[key]; ok && owner != skill.Name {
+				report.warnf("alias %s of skill %s collides with skill %s", alias, skill.Name, owner)
+				continue
+			}
+			owners[key] = skill.Name
+		}
+	}
+}
+
+func unmatchedNames(skills []installer.Skill, names []string) []string {
+	var unmatched []string
+	for _, name := range names {
+		found := false
+		for _, skill := range skills {
+			if skillMatches(skill, name) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			unmatched = append(unmatched, name)
+		}
+	}
+	return unmatched
+}
+
+func findNamedSkill(skills []installer.Skill, roots []string, name string) (installer.Skill, error) {
+	var matches []installer.Skill
+	for _, skill := range skills {
+		if strings.EqualFold(skill.Name, name) {
+			matches = append(matches, skill)
+		}
+	}
+	if len(matches) == 0 {
+		for _, skill := range skills {
+			if skillMatches(skill, name) || strings.Contains(name, "/") && relPathMatches(skillRelPath(roots, skill), name) {
+				matches = append(matches, skill)
+			}
+		}
+	}
+	switch len(matches) {
+	case 1:
+		return matches[0], nil
+	case 0:
+		if suggestion, ok := closestSkillName(skills, name); ok {
+			return installer.Skill{}, fmt.Errorf("no skill named %s; did you mean %s?", name, suggestion)
+		}
+		return installer.Skill{}, fmt.Errorf("no skill named %s", name)
+	}
+	paths := make([]string, 0, len(matches))
+	for _, skill := range matches {
+		paths = append(paths, skillRelPath(roots, skill))
+	}
+	sort.Strings(paths)
+	return installer.Skill{}, fmt.Errorf("skill name %s is ambiguous; pass one of these paths instead: %s", name, strings.Join(paths, ", "))
+}
+
+func skillRelPath(roots []string, skill installer.Skill) string {
+	for _, root := range roots {
+		if rel, err := filepath.Rel(root, skill.Path); err == nil && !strings.HasPrefix(rel, "..") {
+			return filepath.ToSlash(rel)
+		}
+	}
+	return skill.Path
+}
+
+func relPathMatches(rel, name string) bool {
+	name = strings.Trim(filepath.ToSlash(name), "/")
+	return strings.EqualFold(rel, name) || strings.HasSuffix(strings.ToLower(rel), "/"+strings.ToLower(name))
+}
+
+func closestSkillName(skills []installer.Skill, name string) (string, bool) {
+	best := ""
+	bestDistance := max(3, len(name)/3+1)
+	for _, skill := range skills {
+		if distance := levenshtein(strings.ToLower(name), strings.ToLower(skill.Name)); distance < bestDistance {
+			best, bestDistance = skill.Name, distance
+		}
+	}
+	return best, best != ""
+}
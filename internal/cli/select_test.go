@@ -0,0 +1,110 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"agent-skills/internal/installer"
+)
+
+func TestSkillMatchesText(t *testing.T) {
+	skill := installer.Skill{Name: "pr-helper", Description: "Reviews Pull Requests for style issues", Tags: []string{"git"}}
+	tests := []struct {
+		query string
+		want  bool
+	}{
+		{"pull request", true},
+		{"STYLE", true},
+		{"helper", true},
+		{"GIT", true},
+		{"deploy", false},
+	}
+	for _, tt := range tests {
+		if got := skillMatchesText(skill, tt.query); got != tt.want {
+			t.Errorf("skillMatchesText(%q) = %v, want %v", tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestMatchFilterCombinesWithTags(t *testing.T) {
+	skills := []installer.Skill{
+		{Name: "alpha", Description: "Writes release notes", Tags: []string{"docs"}},
+		{Name: "beta", Description: "Drafts release announcements", Tags: []string{"comms"}},
+		{Name: "gamma", Description: "Formats code"},
+	}
+	decisions := newSkillDecisions(skills)
+	if err := filterByField(decisions, "--match", "release", func(s installer.Skill) bool { return skillMatchesText(s, "release") }); err != nil {
+		t.Fatal(err)
+	}
+	if err := filterByField(decisions, "--tag", "docs", func(s installer.Skill) bool { return hasTag(s, "docs") }); err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, skill := range includedSkills(decisions) {
+		names = append(names, skill.Name)
+	}
+	if want := []string{"alpha"}; !reflect.DeepEqual(names, want) {
+		t.Fatalf("included %v, want %v", names, want)
+	}
+	if err := filterByField(decisions, "--match", "nothing", func(s installer.Skill) bool { return skillMatchesText(s, "nothing") }); err == nil {
+		t.Fatal("a --match with no hits succeeded")
+	}
+}
+
+func TestInstallMatchByDescriptionOnly(t *testing.T) {
+	repo := t.TempDir()
+	for name, description := range map[string]string{"notes": "Summarise meeting transcripts", "lint": "Checks style"} {
+		writeTestFile(t, filepath.Join(repo, "skills", name, "SKILL.md"), "---\nname: "+name+"\ndescription: "+description+"\n---\n")
+	}
+	home := t.TempDir()
+	target := filepath.Join(home, ".claude", "skills")
+	if err := os.MkdirAll(target, 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := runAskill(t, home, "--repo", repo, "--target", "claude-global", "--yes", "--match", "TRANSCRIPT"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(target, "notes", "SKILL.md")); err != nil {
+		t.Errorf("description match was not installed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(target, "lint")); err == nil {
+		t.Error("non-matching skill was installed")
+	}
+	if _, err := runAskill(t, home, "--repo", repo, "--target", "claude-global", "--yes", "--match", "deploy"); err == nil {
+		t.Error("--match with no hits succeeded")
+	}
+}
+
+func TestInstallSkipsDisabledSkills(t *testing.T) {
+	repo := testSkillsRepo(t, "active")
+	writeTestFile(t, filepath.Join(repo, "skills", "parked", "SKILL.md"), "---\nname: parked\nenabled: false\n---\n")
+	home := t.TempDir()
+	target := filepath.Join(home, ".claude", "skills")
+	if err := os.MkdirAll(target, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	args := []string{"--repo", repo, "--target", "claude-global", "--yes"}
+
+	if _, err := runAskill(t, home, args...); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(target, "active")); err != nil {
+		t.Errorf("enabled skill was not installed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(target, "parked")); err == nil {
+		t.Error("disabled skill was installed without --include-disabled")
+	}
+	if _, err := runAskill(t, home, append(args, "--skills", "parked")...); err == nil {
+		t.Error("naming a disabled skill without --include-disabled succeeded")
+	}
+
+	if _, err := runAskill(t, home, append(args, "--include-disabled")...); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(target, "parked")); err != nil {
+		t.Errorf("--include-disabled did not install the disabled skill: %v", err)
+	}
+}
@@ -0,0 +1,29 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"agent-skills/internal/installer"
+)
+
+func writeSourceStamp(dest string, sources []lockSource, skill installer.Skill) error {
+	source, rel, ok := sourceFor(sources, skill.Path)
+	if !ok {
+		source, rel = lockSource{source: absDir(skill.Path)}, "."
+	}
+	commit := source.commit
+	if commit == "" {
+		commit = "unknown (not a git checkout)"
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "skill: %s\n", skill.Name)
+	fmt.Fprintf(&b, "source: %s\n", source.source)
+	fmt.Fprintf(&b, "path: %s\n", rel)
+	fmt.Fprintf(&b, "commit: %s\n", commit)
+	fmt.Fprintf(&b, "installed: %s\n", time.Now().UTC().Format(time.RFC3339))
+	return os.WriteFile(filepath.Join(dest, installer.SourceStampFile), []byte(b.String()), 0o644)
+}
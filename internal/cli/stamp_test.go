@@ -0,0 +1,75 @@
+package cli
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"agent-skills/internal/installer"
+)
+
+func readStamp(t *testing.T, dest string) map[string]string {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join(dest, installer.SourceStampFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	fields := map[string]string{}
+	for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+		key, value, ok := strings.Cut(line, ": ")
+		if !ok {
+			t.Fatalf("malformed stamp line %q", line)
+		}
+		fields[key] = value
+	}
+	return fields
+}
+
+func TestWriteSourceStampRecordsRepoAndCommit(t *testing.T) {
+	remote := testRemote(t, "review")
+	clone := filepath.Join(t.TempDir(), "clone")
+	gitCmd(t, filepath.Dir(clone), "clone", "-q", remote, clone)
+	commit := gitCmd(t, clone, "rev-parse", "HEAD")
+	sources := lockSources(context.Background(), []string{clone})
+	skill := installer.Skill{Name: "review", Path: filepath.Join(clone, "skills", "review")}
+	dest := t.TempDir()
+
+	if err := writeSourceStamp(dest, sources, skill); err != nil {
+		t.Fatal(err)
+	}
+	stamp := readStamp(t, dest)
+	want := map[string]string{
+		"skill":  "review",
+		"source": remote,
+		"path":   "skills/review",
+		"commit": commit,
+	}
+	for key, value := range want {
+		if stamp[key] != value {
+			t.Errorf("%s = %q, want %q", key, stamp[key], value)
+		}
+	}
+	if _, err := time.Parse(time.RFC3339, stamp["installed"]); err != nil {
+		t.Errorf("installed = %q: %v", stamp["installed"], err)
+	}
+}
+
+func TestWriteSourceStampOutsideGit(t *testing.T) {
+	root := t.TempDir()
+	skill := installer.Skill{Name: "local", Path: filepath.Join(root, "local")}
+	dest := t.TempDir()
+
+	if err := writeSourceStamp(dest, lockSources(context.Background(), []string{root}), skill); err != nil {
+		t.Fatal(err)
+	}
+	stamp := readStamp(t, dest)
+	if stamp["source"] != root || stamp["path"] != "local" {
+		t.Errorf("stamp = %v, want source %s and path local", stamp, root)
+	}
+	if !strings.HasPrefix(stamp["commit"], "unknown") {
+		t.Errorf("commit = %q, want unknown", stamp["commit"])
+	}
+}
@@ -0,0 +1,151 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"agent-skills/internal/installer"
+)
+
+type setupStatus struct {
+	RepoRoot     string         `json:"repo_root"`
+	RepoError    string         `json:"repo_error,omitempty"`
+	ConfigPath   string         `json:"config_path"`
+	ConfigExists bool           `json:"config_exists"`
+	InstallMode  installer.Mode `json:"install_mode"`
+	Targets      []targetStatus `json:"targets"`
+}
+
+type targetStatus struct {
+	Type     installer.TargetType `json:"type"`
+	Label    string               `json:"label"`
+	Path     string               `json:"path"`
+	Exists   bool                 `json:"exists"`
+	Skills   int                  `json:"skills"`
+	Dangling int                  `json:"dangling"`
+	LinkedTo string               `json:"linked_to,omitempty"`
+}
+
+func runStatusCommand(args []string, cmdName string) error {
+	fs := flag.NewFlagSet(cmdName+" status", flag.ContinueOnError)
+	fs.SetOutput(os.Stderr)
+	var projectPath string
+	var jsonOutput bool
+	fs.StringVar(&projectPath, "project", "", "also show project-local targets under this path")
+	fs.StringVar(&projectPath, "p", "", "alias for --project")
+	fs.BoolVar(&jsonOutput, "json", false, "print JSON instead of text")
+	fs.Usage = func() {
+		out := fs.Output()
+		fmt.Fprintf(out, "Usage: %s status [options]\n\n", cmdName)
+		fmt.Fprintln(out, "Summarize the skills repo, config, and installed skills per target.")
+		fmt.Fprintln(out)
+		fmt.Fprintln(out, "Options:")
+		tw := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(tw, "  -p, --project\tAlso show project-local targets under this path")
+		fmt.Fprintln(tw, "  --json\tPrint JSON instead of text")
+		fmt.Fprintln(tw, "  -h, --help\tShow help")
+		_ = tw.Flush()
+	}
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+
+	configPath, err := configFilePath()
+	if err != nil {
+		return err
+	}
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("get working directory: %w", err)
+	}
+	defaultRoot, _ := detectRepoRoot("")
+	status := setupStatus{
+		ConfigPath:  configPath,
+		InstallMode: resolveInstallMode(withDefaultConfig(cfg, defaultRoot, cwd)),
+		Targets:     []targetStatus{},
+	}
+	if _, err := os.Stat(configPath); err == nil {
+		status.ConfigExists = true
+	}
+	root, cleanup, err := resolveRepoRoot(context.Background(), cloneOptions{}, "")
+	if err != nil {
+		status.RepoError = err.Error()
+	} else {
+		status.RepoRoot = root
+		if cleanup != nil {
+			defer cleanup()
+		}
+	}
+
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return fmt.Errorf("determine home directory: %w", err)
+	}
+	targets, err := discoverTargets(homeDir, projectPath, cfg, true)
+	if err != nil {
+		return err
+	}
+	for _, target := range targets {
+		item := targetStatus{Type: target.Type, Label: target.Label, Path: target.Path, Exists: target.Exists}
+		if meta, ok := linkedRoot(target); ok {
+			item.LinkedTo = meta.Source
+			status.Targets = append(status.Targets, item)
+			continue
+		}
+		entries, err := installer.InstalledEntries(target)
+		if err != nil {
+			return fmt.Errorf("read target %s: %w", target.Path, err)
+		}
+		for _, entry := range entries {
+			if entry.Status == installer.EntryDangling {
+				item.Dangling++
+			} else {
+				item.Skills++
+			}
+		}
+		status.Targets = append(status.Targets, item)
+	}
+
+	if jsonOutput {
+		return printJSON(status)
+	}
+	if status.RepoError != "" {
+		fmt.Printf("Repo root: unresolved (%s)\n", status.RepoError)
+	} else {
+		fmt.Printf("Repo root: %s\n", status.RepoRoot)
+	}
+	configState := "exists"
+	if !status.ConfigExists {
+		configState = fmt.Sprintf("not created; run %s config --init", cmdName)
+	}
+	fmt.Printf("Config: %s (%s)\n", status.ConfigPath, configState)
+	fmt.Printf("Default install mode: %s\n", status.InstallMode)
+	fmt.Println()
+	if len(status.Targets) == 0 {
+		fmt.Println("No install targets found")
+		return nil
+	}
+	tw := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "TARGET\tPATH\tSKILLS\tDANGLING")
+	for _, target := range status.Targets {
+		skills := fmt.Sprint(target.Skills)
+		if target.LinkedTo != "" {
+			skills = "linked to " + target.LinkedTo
+		} else if !target.Exists {
+			skills = "missing"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%d\n", target.Type, target.Path, skills, target.Dangling)
+	}
+	return tw.Flush()
+}
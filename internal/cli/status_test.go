@@ -0,0 +1,49 @@
+package cli
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestStatusReportsLinkedTarget(t *testing.T) {
+	repo := testSkillsRepo(t, "one", "two", "three")
+	home := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(home, ".claude", "skills"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := runAskill(t, home, "--repo", repo, "--target", "claude-global", "--link-all", "--yes"); err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := runAskill(t, home, "status", "--json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var status setupStatus
+	if err := json.Unmarshal([]byte(out), &status); err != nil {
+		t.Fatalf("%v\n%s", err, out)
+	}
+	var linked *targetStatus
+	for i := range status.Targets {
+		if status.Targets[i].Type == "claude-global" {
+			linked = &status.Targets[i]
+		}
+	}
+	if linked == nil {
+		t.Fatalf("claude-global missing from status:\n%s", out)
+	}
+	if linked.LinkedTo != filepath.Join(repo, "skills") || linked.Skills != 0 {
+		t.Fatalf("claude-global = %+v, want it linked to %s", *linked, filepath.Join(repo, "skills"))
+	}
+
+	out, err = runAskill(t, home, "status")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out, "linked to "+filepath.Join(repo, "skills")) {
+		t.Fatalf("status output = %q, want the linked source", out)
+	}
+}
@@ -0,0 +1,148 @@
+package cli
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+)
+
+const redacted = "[REDACTED]"
+
+var tokenEnvVars = []string{"ASKILL_GIT_TOKEN", "GITHUB_TOKEN"}
+
+func (o cloneOptions) withEnvToken() cloneOptions {
+	if o.token != "" {
+		return o
+	}
+	for _, name := range tokenEnvVars {
+		if token := strings.TrimSpace(os.Getenv(name)); token != "" {
+			debugf("using the access token from $%s", name)
+			o.token = token
+			if name == "GITHUB_TOKEN" {
+				o.tokenHost = "github.com"
+			}
+			return o
+		}
+	}
+	return o
+}
+
+func (o cloneOptions) authHint() string {
+	if o.token != "" {
+		return "check that the access token is valid and can read this repo"
+	}
+	return "for private HTTPS repos pass --repo-token-file or set ASKILL_GIT_TOKEN or GITHUB_TOKEN; for SSH use a git@ URL, with --ssh-key if needed"
+}
+
+func readTokenFile(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("read token file: %w", err)
+	}
+	token := strings.TrimSpace(string(data))
+	if token == "" {
+		return "", fmt.Errorf("token file %s is empty", path)
+	}
+	if strings.ContainsAny(token, "\r\n") {
+		return "", fmt.Errorf("token file %s must contain a single line", path)
+	}
+	return token, nil
+}
+
+func (o cloneOptions) authHeader() string {
+	credentials := base64.StdEncoding.EncodeToString([]byte("x-access-token:" + o.token))
+	return "Authorization: Basic " + credentials
+}
+
+func (o cloneOptions) forRepo(repoURL string) cloneOptions {
+	if o.token == "" || o.tokenHost != "" {
+		return o
+	}
+	if u, err := url.Parse(repoURL); err == nil && u.Scheme == "https" {
+		o.tokenHost = u.Host
+	}
+	return o
+}
+
+func (o cloneOptions) tokenEnv() []string {
+	if o.token == "" || o.tokenHost == "" {
+		return nil
+	}
+	return []string{
+		"GIT_CONFIG_COUNT=1",
+		"GIT_CONFIG_KEY_0=http.https://" + o.tokenHost + "/.extraHeader",
+		"GIT_CONFIG_VALUE_0=" + o.authHeader(),
+	}
+}
+
+func (o cloneOptions) authorize(req *http.Request) {
+	if o.token == "" {
+		return
+	}
+	switch req.URL.Hostname() {
+	case "github.com", "api.github.com", "codeload.github.com":
+		req.Header.Set("Authorization", "Bearer "+o.token)
+	}
+}
+
+func (o cloneOptions) secrets() []string {
+	if o.token == "" {
+		return nil
+	}
+	return []string{o.token, strings.TrimPrefix(o.authHeader(), "Authorization: Basic ")}
+}
+
+func (o cloneOptions) redact(text string) string {
+	for _, secret := range o.secrets() {
+		text = strings.ReplaceAll(text, secret, redacted)
+	}
+	return text
+}
+
+func (o cloneOptions) redactError(err error) error {
+	if err == nil || o.token == "" {
+		return err
+	}
+	var already redactedError
+	if errors.As(err, &already) {
+		return err
+	}
+	return redactedError{err: err, clone: o}
+}
+
+func (o cloneOptions) stderr() io.Writer {
+	if o.token == "" {
+		return os.Stderr
+	}
+	return redactWriter{w: os.Stderr, clone: o}
+}
+
+type redactedError struct {
+	err   error
+	clone cloneOptions
+}
+
+func (e redactedError) Error() string {
+	return e.clone.redact(e.err.Error())
+}
+
+func (e redactedError) Unwrap() error {
+	return e.err
+}
+
+type redactWriter struct {
+	w     io.Writer
+	clone cloneOptions
+}
+
+func (r redactWriter) Write(p []byte) (int, error) {
+	if _, err := io.WriteString(r.w, r.clone.redact(string(p))); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
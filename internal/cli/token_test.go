@@ -0,0 +1,85 @@
+package cli
+
+import (
+	"context"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+func captureStderr(t *testing.T, fn func()) string {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	previous := os.Stderr
+	os.Stderr = w
+	done := make(chan string)
+	go func() {
+		data, _ := io.ReadAll(r)
+		done <- string(data)
+	}()
+	defer func() { os.Stderr = previous }()
+	fn()
+	w.Close()
+	return <-done
+}
+
+func TestFailedCloneIsRedacted(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	const token = "s3cr3t-token-value"
+	clone := cloneOptions{token: token, noCache: true}
+	repo := "file://" + t.TempDir() + "/" + token + "/repo.git"
+
+	var cloneErr error
+	stderr := captureStderr(t, func() {
+		_, _, cloneErr = cloneRepo(context.Background(), clone, repo)
+		cloneErr = clone.redactError(cloneErr)
+	})
+	if cloneErr == nil {
+		t.Fatal("clone of a missing repo succeeded")
+	}
+	if msg := cloneErr.Error(); strings.Contains(msg, token) || !strings.Contains(msg, redacted) {
+		t.Errorf("error not redacted: %s", msg)
+	}
+	if strings.Contains(stderr, token) || !strings.Contains(stderr, redacted) {
+		t.Errorf("stderr not redacted: %s", stderr)
+	}
+}
+
+func TestTokenEnvIsScopedToRepoHost(t *testing.T) {
+	tests := []struct {
+		name    string
+		clone   cloneOptions
+		repoURL string
+		wantKey string
+	}{
+		{"ASKILL_GIT_TOKEN on https", cloneOptions{token: "t"}, "https://git.example.com/team/skills.git", "http.https://git.example.com/.extraHeader"},
+		{"GITHUB_TOKEN stays on github.com", cloneOptions{token: "t", tokenHost: "github.com"}, "https://gitlab.com/team/skills.git", "http.https://github.com/.extraHeader"},
+		{"ssh remote gets no header", cloneOptions{token: "t"}, "git@github.com:team/skills.git", ""},
+		{"unscoped token gets no header", cloneOptions{token: "t"}, "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clone := tt.clone
+			if tt.repoURL != "" {
+				clone = clone.forRepo(tt.repoURL)
+			}
+			env := clone.tokenEnv()
+			if tt.wantKey == "" {
+				if env != nil {
+					t.Fatalf("tokenEnv = %v, want none", env)
+				}
+				return
+			}
+			if len(env) != 3 || env[1] != "GIT_CONFIG_KEY_0="+tt.wantKey {
+				t.Fatalf("tokenEnv = %v, want key %s", env, tt.wantKey)
+			}
+		})
+	}
+}
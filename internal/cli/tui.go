@@ -7,12 +7,15 @@ import (
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
 
 	"agent-skills/internal/installer"
 )
 
 var errCanceled = errors.New("canceled")
 
+var errBack = errors.New("back")
+
 var (
 	titleStyle    = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("69"))
 	cursorStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("212")).Bold(true)
@@ -20,13 +23,166 @@ var (
 	helpStyle     = lipgloss.NewStyle().Foreground(lipgloss.Color("241"))
 	defaultStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("81")).Bold(true)
 	warningStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("220")).Bold(true)
+	keyStyle      = lipgloss.NewStyle().Foreground(lipgloss.Color("212")).Bold(true).Width(14)
+	overlayStyle  = lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).BorderForeground(lipgloss.Color("69")).Padding(1, 2)
 )
 
+type keyHelp struct {
+	key    string
+	action string
+}
+
+var multiSelectKeys = []keyHelp{
+	{"j / ↓", "move down"},
+	{"k / ↑", "move up"},
+	{"space", "select or deselect item"},
+	{"a", "toggle all shown items"},
+	{"/", "filter items; esc clears the filter"},
+	{"enter", "confirm selection"},
+	{"q / esc", "cancel and quit"},
+	{"?", "toggle this help"},
+}
+
+var multiSelectStepKeys = []keyHelp{
+	{"j / ↓", "move down"},
+	{"k / ↑", "move up"},
+	{"space", "select or deselect item"},
+	{"a", "toggle all shown items"},
+	{"/", "filter items; esc clears the filter"},
+	{"p", "toggle the SKILL.md preview"},
+	{"enter", "confirm selection"},
+	{"esc", "go back to the previous step"},
+	{"q", "cancel and quit"},
+	{"?", "toggle this help"},
+}
+
+var singleSelectStepKeys = []keyHelp{
+	{"j / ↓", "move down"},
+	{"k / ↑", "move up"},
+	{"/", "filter items; esc clears the filter"},
+	{"enter", "confirm choice"},
+	{"esc", "go back to the previous step"},
+	{"q", "cancel and quit"},
+	{"?", "toggle this help"},
+}
+
+var singleSelectKeys = []keyHelp{
+	{"j / ↓", "move down"},
+	{"k / ↑", "move up"},
+	{"/", "filter items; esc clears the filter"},
+	{"enter", "confirm choice"},
+	{"q / esc", "cancel and quit"},
+	{"?", "toggle this help"},
+}
+
+func renderHelpOverlay(keys []keyHelp) string {
+	var b strings.Builder
+	b.WriteString(titleStyle.Render("Keyboard shortcuts"))
+	b.WriteString("\n\n")
+	for _, k := range keys {
+		b.WriteString(keyStyle.Render(k.key))
+		b.WriteString(k.action)
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+	b.WriteString(helpStyle.Render("press any key to return"))
+	return overlayStyle.Render(b.String()) + "\n"
+}
+
+type listFilter struct {
+	typing bool
+	query  string
+}
+
+func (f *listFilter) handleKey(msg tea.KeyMsg) (handled, changed bool) {
+	if !f.typing {
+		switch {
+		case msg.String() == "/":
+			f.typing = true
+			return true, false
+		case msg.String() == "esc" && f.query != "":
+			f.query = ""
+			return true, true
+		}
+		return false, false
+	}
+	switch msg.Type {
+	case tea.KeyEsc:
+		f.typing = false
+		changed = f.query != ""
+		f.query = ""
+		return true, changed
+	case tea.KeyEnter:
+		f.typing = false
+		return true, false
+	case tea.KeyBackspace:
+		if runes := []rune(f.query); len(runes) > 0 {
+			f.query = string(runes[:len(runes)-1])
+			return true, true
+		}
+		return true, false
+	case tea.KeyRunes, tea.KeySpace:
+		f.query += string(msg.Runes)
+		return true, true
+	case tea.KeyUp, tea.KeyDown, tea.KeyCtrlC:
+		return false, false
+	}
+	return true, false
+}
+
+func (f listFilter) visible(items []string) []int {
+	indices := make([]int, 0, len(items))
+	for i, item := range items {
+		if f.query == "" || fuzzyMatch(ansi.Strip(item), f.query) {
+			indices = append(indices, i)
+		}
+	}
+	return indices
+}
+
+func (f listFilter) view() string {
+	if !f.typing && f.query == "" {
+		return ""
+	}
+	line := "/" + f.query
+	if f.typing {
+		line += cursorStyle.Render("_")
+	}
+	return line + "\n"
+}
+
+func fuzzyMatch(label, query string) bool {
+	label, query = strings.ToLower(label), strings.ToLower(query)
+	if strings.Contains(label, query) {
+		return true
+	}
+	rest := []rune(query)
+	for _, r := range label {
+		if len(rest) > 0 && r == rest[0] {
+			rest = rest[1:]
+		}
+	}
+	return len(rest) == 0
+}
+
 func selectIndicesTUI(title string, items []string, selected map[int]bool, showDefaultLabel bool) ([]int, error) {
 	if len(items) == 0 {
 		return nil, errors.New("no items to select")
 	}
-	model := newMultiSelectModel(title, items, selected, showDefaultLabel)
+	return runMultiSelect(newMultiSelectModel(title, items, selected, showDefaultLabel))
+}
+
+func selectIndicesStepTUI(title string, items []string, selected map[int]bool, previewPaths []string) ([]int, error) {
+	if len(items) == 0 {
+		return nil, errors.New("no items to select")
+	}
+	model := newMultiSelectModel(title, items, selected, false)
+	model.allowBack = true
+	model.previewPaths = previewPaths
+	return runMultiSelect(model)
+}
+
+func runMultiSelect(model multiSelectModel) ([]int, error) {
 	program := tea.NewProgram(model, tea.WithAltScreen())
 	finalModel, err := program.Run()
 	if err != nil {
@@ -36,12 +192,23 @@ func selectIndicesTUI(title string, items []string, selected map[int]bool, showD
 	if !ok {
 		return nil, errors.New("unexpected TUI state")
 	}
+	if finalState.back {
+		return finalState.selectedIndices(), errBack
+	}
 	if finalState.canceled {
 		return nil, errCanceled
 	}
 	return finalState.selectedIndices(), nil
 }
 
+func selectionMap(indices []int) map[int]bool {
+	selected := make(map[int]bool, len(indices))
+	for _, idx := range indices {
+		selected[idx] = true
+	}
+	return selected
+}
+
 type multiSelectModel struct {
 	title            string
 	items            []string
@@ -49,8 +216,18 @@ type multiSelectModel struct {
 	selected         map[int]bool
 	defaults         map[int]bool
 	showDefaultLabel bool
+	showHelp         bool
+	allowBack        bool
+	back             bool
 	canceled         bool
 	confirmed        bool
+	width            int
+	height           int
+	offset           int
+	filter           listFilter
+	previewPaths     []string
+	previews         map[int][]string
+	showPreview      bool
 }
 
 func newMultiSelectModel(title string, items []string, selected map[int]bool, showDefaultLabel bool) multiSelectModel {
@@ -67,6 +244,7 @@ func newMultiSelectModel(title string, items []string, selected map[int]bool, sh
 		selected:         selected,
 		defaults:         defaults,
 		showDefaultLabel: showDefaultLabel,
+		previews:         make(map[int][]string),
 	}
 }
 
@@ -76,9 +254,34 @@ func (m multiSelectModel) Init() tea.Cmd {
 
 func (m multiSelectModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		m.keepCursorVisible()
 	case tea.KeyMsg:
+		if m.showHelp && msg.String() != "ctrl+c" {
+			m.showHelp = false
+			return m, nil
+		}
+		if handled, changed := m.filter.handleKey(msg); handled {
+			if changed {
+				m.cursor, m.offset = 0, 0
+			}
+			m.keepCursorVisible()
+			m.loadPreview()
+			return m, nil
+		}
+		visible := m.filter.visible(m.items)
 		switch msg.String() {
-		case "ctrl+c", "q", "esc":
+		case "?":
+			m.showHelp = true
+		case "esc":
+			if m.allowBack {
+				m.back = true
+			} else {
+				m.canceled = true
+			}
+			return m, tea.Quit
+		case "ctrl+c", "q":
 			m.canceled = true
 			return m, tea.Quit
 		case "enter":
@@ -88,19 +291,32 @@ func (m multiSelectModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			if m.cursor > 0 {
 				m.cursor--
 			}
+			m.keepCursorVisible()
+			m.loadPreview()
 		case "down", "j":
-			if m.cursor < len(m.items)-1 {
+			if m.cursor < len(visible)-1 {
 				m.cursor++
 			}
+			m.keepCursorVisible()
+			m.loadPreview()
+		case "p":
+			if m.previewPaths != nil {
+				m.showPreview = !m.showPreview
+				m.loadPreview()
+				m.keepCursorVisible()
+			}
 		case " ":
-			m.selected[m.cursor] = !m.selected[m.cursor]
+			if len(visible) > 0 {
+				idx := visible[m.cursor]
+				m.selected[idx] = !m.selected[idx]
+			}
 		case "a":
-			if len(m.selected) == len(m.items) {
-				m.selected = make(map[int]bool)
-			} else {
-				for i := range m.items {
-					m.selected[i] = true
-				}
+			all := true
+			for _, idx := range visible {
+				all = all && m.selected[idx]
+			}
+			for _, idx := range visible {
+				m.selected[idx] = !all
 			}
 		}
 	}
@@ -108,12 +324,33 @@ func (m multiSelectModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 }
 
 func (m multiSelectModel) View() string {
+	if m.showHelp {
+		if m.allowBack {
+			return renderHelpOverlay(multiSelectStepKeys)
+		}
+		return renderHelpOverlay(multiSelectKeys)
+	}
 	var b strings.Builder
 	b.WriteString(titleStyle.Render(m.title))
 	b.WriteString("\n\n")
-	for i, item := range m.items {
+	b.WriteString(m.filter.view())
+	visible := m.filter.visible(m.items)
+	rows := m.visibleRows()
+	windowed := rows < len(visible)
+	if windowed {
+		if m.offset > 0 {
+			b.WriteString(helpStyle.Render(fmt.Sprintf("  ↑ %d more", m.offset)))
+		}
+		b.WriteString("\n")
+	}
+	if len(visible) == 0 {
+		b.WriteString(helpStyle.Render("  no matches"))
+		b.WriteString("\n")
+	}
+	for pos := m.offset; pos < m.offset+rows; pos++ {
+		i := visible[pos]
 		cursor := " "
-		if m.cursor == i {
+		if m.cursor == pos {
 			cursor = cursorStyle.Render(">")
 		}
 		check := " "
@@ -124,14 +361,110 @@ func (m multiSelectModel) View() string {
 		if m.showDefaultLabel && m.defaults[i] {
 			label = " " + defaultStyle.Render("default")
 		}
-		b.WriteString(fmt.Sprintf("%s [%s] %s%s\n", cursor, check, item, label))
+		b.WriteString(fmt.Sprintf("%s [%s] %s%s\n", cursor, check, m.items[i], label))
+	}
+	if windowed {
+		if below := len(visible) - m.offset - rows; below > 0 {
+			b.WriteString(helpStyle.Render(fmt.Sprintf("  ↓ %d more", below)))
+		}
+		b.WriteString("\n")
 	}
+	b.WriteString(m.previewView(visible))
 	b.WriteString("\n")
-	b.WriteString(helpStyle.Render("j/k or ↑/↓ to move, space to select, a to toggle all, enter to confirm, q to quit"))
+	b.WriteString(helpStyle.Render(m.helpLine()))
 	b.WriteString("\n")
 	return b.String()
 }
 
+const previewLines = 10
+
+func (m multiSelectModel) loadPreview() {
+	if !m.showPreview {
+		return
+	}
+	visible := m.filter.visible(m.items)
+	if len(visible) == 0 {
+		return
+	}
+	idx := visible[m.cursor]
+	if _, ok := m.previews[idx]; ok || idx >= len(m.previewPaths) {
+		return
+	}
+	lines, err := installer.ReadSkillBody(m.previewPaths[idx], previewLines)
+	if err != nil {
+		lines = []string{fmt.Sprintf("cannot read %s: %v", m.previewPaths[idx], err)}
+	} else if len(lines) == 0 {
+		lines = []string{"(empty SKILL.md body)"}
+	}
+	m.previews[idx] = lines
+}
+
+func (m multiSelectModel) previewView(visible []int) string {
+	if !m.showPreview || len(visible) == 0 {
+		return ""
+	}
+	width := m.width
+	if width <= 0 {
+		width = 80
+	}
+	var b strings.Builder
+	b.WriteString("\n")
+	b.WriteString(helpStyle.Render(strings.Repeat("─", width)))
+	b.WriteString("\n")
+	for _, line := range m.previews[visible[m.cursor]] {
+		b.WriteString(ansi.Truncate(line, width, "…"))
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+func (m multiSelectModel) helpLine() string {
+	if m.filter.typing {
+		return "type to filter, ↑/↓ to move, enter to keep the filter, esc to clear it"
+	}
+	if m.allowBack && m.previewPaths != nil {
+		return "j/k or ↑/↓ to move, space to select, a to toggle all, / to filter, p to preview, enter to confirm, esc to go back, q to quit, ? for help"
+	}
+	if m.allowBack {
+		return "j/k or ↑/↓ to move, space to select, a to toggle all, / to filter, enter to confirm, esc to go back, q to quit, ? for help"
+	}
+	return "j/k or ↑/↓ to move, space to select, a to toggle all, / to filter, enter to confirm, q to quit, ? for help"
+}
+
+func (m multiSelectModel) visibleRows() int {
+	count := len(m.filter.visible(m.items))
+	if m.height <= 0 {
+		return count
+	}
+	chrome := 3 + strings.Count(m.filter.view(), "\n")
+	if m.showPreview {
+		chrome += 2 + previewLines
+	}
+	if count == 0 {
+		chrome++
+	}
+	if m.width > 0 {
+		chrome += (lipgloss.Width(m.helpLine()) + m.width - 1) / m.width
+	} else {
+		chrome++
+	}
+	if m.height-chrome >= count {
+		return count
+	}
+	return max(1, m.height-chrome-2)
+}
+
+func (m *multiSelectModel) keepCursorVisible() {
+	rows := m.visibleRows()
+	if m.cursor < m.offset {
+		m.offset = m.cursor
+	}
+	if m.cursor >= m.offset+rows {
+		m.offset = m.cursor - rows + 1
+	}
+	m.offset = max(0, min(m.offset, len(m.filter.visible(m.items))-rows))
+}
+
 func (m multiSelectModel) selectedIndices() []int {
 	if len(m.selected) == 0 {
 		return nil
@@ -149,7 +482,10 @@ func selectIndexTUI(title string, items []string, defaultIndex int, banner strin
 	if len(items) == 0 {
 		return -1, errors.New("no items to select")
 	}
-	model := newSingleSelectModel(title, items, defaultIndex, banner)
+	return runSingleSelect(newSingleSelectModel(title, items, defaultIndex, banner))
+}
+
+func runSingleSelect(model singleSelectModel) (int, error) {
 	program := tea.NewProgram(model, tea.WithAltScreen())
 	finalModel, err := program.Run()
 	if err != nil {
@@ -159,6 +495,9 @@ func selectIndexTUI(title string, items []string, defaultIndex int, banner strin
 	if !ok {
 		return -1, errors.New("unexpected TUI state")
 	}
+	if finalState.back {
+		return -1, errBack
+	}
 	if finalState.canceled {
 		return -1, errCanceled
 	}
@@ -172,7 +511,11 @@ type singleSelectModel struct {
 	selectedIndex int
 	defaultIndex  int
 	banner        string
+	showHelp      bool
+	allowBack     bool
+	back          bool
 	canceled      bool
+	filter        listFilter
 }
 
 func newSingleSelectModel(title string, items []string, defaultIndex int, banner string) singleSelectModel {
@@ -195,19 +538,42 @@ func (m singleSelectModel) Init() tea.Cmd {
 func (m singleSelectModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		if m.showHelp && msg.String() != "ctrl+c" {
+			m.showHelp = false
+			return m, nil
+		}
+		if handled, changed := m.filter.handleKey(msg); handled {
+			if changed {
+				m.cursor = 0
+			}
+			return m, nil
+		}
+		visible := m.filter.visible(m.items)
 		switch msg.String() {
-		case "ctrl+c", "q", "esc":
+		case "?":
+			m.showHelp = true
+		case "esc":
+			if m.allowBack {
+				m.back = true
+			} else {
+				m.canceled = true
+			}
+			return m, tea.Quit
+		case "ctrl+c", "q":
 			m.canceled = true
 			return m, tea.Quit
 		case "enter":
-			m.selectedIndex = m.cursor
+			if len(visible) == 0 {
+				return m, nil
+			}
+			m.selectedIndex = visible[m.cursor]
 			return m, tea.Quit
 		case "up", "k":
 			if m.cursor > 0 {
 				m.cursor--
 			}
 		case "down", "j":
-			if m.cursor < len(m.items)-1 {
+			if m.cursor < len(visible)-1 {
 				m.cursor++
 			}
 		}
@@ -216,6 +582,12 @@ func (m singleSelectModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 }
 
 func (m singleSelectModel) View() string {
+	if m.showHelp {
+		if m.allowBack {
+			return renderHelpOverlay(singleSelectStepKeys)
+		}
+		return renderHelpOverlay(singleSelectKeys)
+	}
 	var b strings.Builder
 	if strings.TrimSpace(m.banner) != "" {
 		b.WriteString(warningStyle.Render(m.banner))
@@ -223,19 +595,32 @@ func (m singleSelectModel) View() string {
 	}
 	b.WriteString(titleStyle.Render(m.title))
 	b.WriteString("\n\n")
-	for i, item := range m.items {
+	b.WriteString(m.filter.view())
+	visible := m.filter.visible(m.items)
+	if len(visible) == 0 {
+		b.WriteString(helpStyle.Render("  no matches"))
+		b.WriteString("\n")
+	}
+	for pos, i := range visible {
 		cursor := " "
-		if m.cursor == i {
+		if m.cursor == pos {
 			cursor = cursorStyle.Render(">")
 		}
 		label := ""
 		if i == m.defaultIndex {
 			label = " " + defaultStyle.Render("default")
 		}
-		b.WriteString(fmt.Sprintf("%s %s%s\n", cursor, item, label))
+		b.WriteString(fmt.Sprintf("%s %s%s\n", cursor, m.items[i], label))
 	}
 	b.WriteString("\n")
-	b.WriteString(helpStyle.Render("j/k or ↑/↓ to move, enter to confirm, q to quit"))
+	switch {
+	case m.filter.typing:
+		b.WriteString(helpStyle.Render("type to filter, ↑/↓ to move, enter to keep the filter, esc to clear it"))
+	case m.allowBack:
+		b.WriteString(helpStyle.Render("j/k or ↑/↓ to move, / to filter, enter to confirm, esc to go back, q to quit, ? for help"))
+	default:
+		b.WriteString(helpStyle.Render("j/k or ↑/↓ to move, / to filter, enter to confirm, q to quit, ? for help"))
+	}
 	b.WriteString("\n")
 	return b.String()
 }
@@ -346,7 +731,12 @@ func promptSkillsRootTUI(defaultRoot string, cfg appConfig, cwd string) (string,
 		paths = append(paths, "bundled")
 		labels = append(labels, "bundled")
 	}
-	if cfg.SkillRepoPath != "" && cfg.SkillRepoPath != "bundled" && cfg.SkillRepoPath != "cwd" {
+	for _, repo := range cfg.Repos {
+		items = append(items, fmt.Sprintf("%s (%s)", repo.Name, repo.Path))
+		paths = append(paths, repo.Path)
+		labels = append(labels, "repo:"+repo.Name)
+	}
+	if _, named := findRepo(cfg.Repos, cfg.SkillRepoPath); !named && cfg.SkillRepoPath != "" && cfg.SkillRepoPath != "bundled" && cfg.SkillRepoPath != "cwd" {
 		items = append(items, fmt.Sprintf("Configured skill repo (%s)", cfg.SkillRepoPath))
 		paths = append(paths, cfg.SkillRepoPath)
 		labels = append(labels, "configured")
@@ -411,15 +801,20 @@ func promptInstallModeTUI(cfg appConfig) (installer.Mode, error) {
 	items := []string{
 		"Copy files (recommended)",
 		"Symlink",
+		"Hardlink files (same filesystem only)",
 	}
 	idx, err := selectIndexTUI("Install mode", items, defaultInstallModeIndex(cfg), "")
 	if err != nil {
 		return "", err
 	}
-	if idx == 0 {
+	switch idx {
+	case 0:
 		return installer.ModeCopy, nil
+	case 2:
+		return installer.ModeHardlink, nil
+	default:
+		return installer.ModeSymlink, nil
 	}
-	return installer.ModeSymlink, nil
 }
 
 func promptOverwriteTUI() (bool, error) {
@@ -427,7 +822,9 @@ func promptOverwriteTUI() (bool, error) {
 		"Skip existing skills",
 		"Overwrite existing skills",
 	}
-	idx, err := selectIndexTUI("Overwrite existing skills?", items, 0, "")
+	model := newSingleSelectModel("Overwrite existing skills?", items, 0, "")
+	model.allowBack = true
+	idx, err := runSingleSelect(model)
 	if err != nil {
 		return false, err
 	}
@@ -446,6 +843,9 @@ func defaultSkillsSourceIndex(cfg appConfig, labels []string) int {
 		}
 		return indexOfLabel(labels, "cwd")
 	default:
+		if idx := indexOfLabel(labels, "repo:"+cfg.SkillRepoPath); idx >= 0 {
+			return idx
+		}
 		if idx := indexOfLabel(labels, "configured"); idx >= 0 {
 			return idx
 		}
@@ -471,10 +871,14 @@ func defaultProjectChoiceIndex(cfg appConfig) int {
 }
 
 func defaultInstallModeIndex(cfg appConfig) int {
-	if strings.EqualFold(cfg.InstallMode, string(installer.ModeCopy)) {
+	switch {
+	case strings.EqualFold(cfg.InstallMode, string(installer.ModeCopy)):
 		return 0
+	case strings.EqualFold(cfg.InstallMode, string(installer.ModeHardlink)):
+		return 2
+	default:
+		return 1
 	}
-	return 1
 }
 
 func indexOfLabel(labels []string, target string) int {
@@ -0,0 +1,178 @@
+package cli
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+
+	"agent-skills/internal/installer"
+)
+
+type managedEntry struct {
+	target installer.Target
+	name   string
+	dest   string
+}
+
+func runUninstallCommand(args []string, cmdName string) error {
+	fs := flag.NewFlagSet(cmdName+" uninstall", flag.ContinueOnError)
+	fs.SetOutput(os.Stderr)
+	var projectPath string
+	var targetList string
+	var all bool
+	var dryRun bool
+	var yes bool
+	fs.StringVar(&projectPath, "project", "", "project path for project-local targets")
+	fs.StringVar(&projectPath, "p", "", "alias for --project")
+	fs.StringVar(&targetList, "target", "", "comma-separated target types to uninstall from (defaults to all)")
+	fs.BoolVar(&all, "all", false, "remove every askill-managed skill")
+	fs.BoolVar(&dryRun, "dry-run", false, "report what would be removed without removing it")
+	fs.BoolVar(&yes, "yes", false, "skip the confirmation prompt")
+	fs.BoolVar(&yes, "y", false, "alias for --yes")
+	fs.Usage = func() {
+		out := fs.Output()
+		fmt.Fprintf(out, "Usage: %s uninstall [options] [--all | skill...]\n\n", cmdName)
+		fmt.Fprintln(out, "Remove askill-managed skills from targets. Unmanaged entries are never touched.")
+		fmt.Fprintln(out)
+		fmt.Fprintln(out, "Options:")
+		tw := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(tw, "  -p, --project\tProject path for project-local targets")
+		fmt.Fprintf(tw, "  --target\tComma-separated target types (%s)\n", joinTargetTypes())
+		fmt.Fprintln(tw, "  --all\tRemove every askill-managed skill")
+		fmt.Fprintln(tw, "  --dry-run\tReport what would be removed without removing it")
+		fmt.Fprintln(tw, "  -y, --yes\tSkip the confirmation prompt")
+		fmt.Fprintln(tw, "  -h, --help\tShow help")
+		_ = tw.Flush()
+	}
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+	names := fs.Args()
+	if all == (len(names) > 0) {
+		return errors.New("specify skill names or --all (but not both)")
+	}
+
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return fmt.Errorf("determine home directory: %w", err)
+	}
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	targets, err := discoverTargets(homeDir, projectPath, cfg, true)
+	if err != nil {
+		return err
+	}
+	if targetList != "" {
+		targets, err = filterTargetTypes(targets, splitList(targetList))
+		if err != nil {
+			return err
+		}
+	}
+
+	wanted := make(map[string]bool, len(names))
+	for _, name := range names {
+		wanted[name] = true
+	}
+	entries, err := managedEntries(targets)
+	if err != nil {
+		return err
+	}
+	var selected []managedEntry
+	for _, entry := range entries {
+		if all || wanted[entry.name] {
+			selected = append(selected, entry)
+		}
+	}
+	if len(selected) == 0 {
+		fmt.Println("No managed skills to remove")
+		return nil
+	}
+
+	if !dryRun && !yes {
+		if !confirm(stdinReader, fmt.Sprintf("Remove %d managed skill(s)? [y/N]: ", len(selected))) {
+			return errors.New("uninstall cancelled")
+		}
+	}
+
+	counts := make(map[installer.TargetType]int)
+	for _, entry := range selected {
+		if dryRun {
+			fmt.Printf("Would remove %s from %s\n", entry.name, entry.target.Label)
+		} else {
+			if err := installer.RemoveSkill(entry.dest); err != nil {
+				return fmt.Errorf("remove %s: %w", entry.dest, err)
+			}
+			fmt.Printf("Removed %s from %s\n", entry.name, entry.target.Label)
+		}
+		counts[entry.target.Type]++
+	}
+	verb := "removed"
+	if dryRun {
+		verb = "to remove"
+	}
+	for _, target := range targets {
+		if n := counts[target.Type]; n > 0 {
+			fmt.Printf("%s: %d %s\n", target.Label, n, verb)
+		}
+	}
+	return nil
+}
+
+func managedEntries(targets []installer.Target) ([]managedEntry, error) {
+	var entries []managedEntry
+	for _, target := range targets {
+		if _, ok := linkedRoot(target); ok {
+			entries = append(entries, managedEntry{target: target, name: installer.AllSkills, dest: target.Path})
+			continue
+		}
+		if !installer.ExistsDir(target.Path) {
+			continue
+		}
+		dirEntries, err := os.ReadDir(target.Path)
+		if err != nil {
+			return nil, fmt.Errorf("read target %s: %w", target.Path, err)
+		}
+		for _, dirEntry := range dirEntries {
+			name := dirEntry.Name()
+			if strings.HasPrefix(name, ".") || installer.IsMetaFile(name) {
+				continue
+			}
+			dest := filepath.Join(target.Path, name)
+			if !installer.IsManagedSkill(dest) {
+				continue
+			}
+			skill := strings.TrimSuffix(name, ".md")
+			if meta, err := installer.ReadMeta(dest); err == nil && meta.Skill != "" {
+				skill = meta.Skill
+			}
+			entries = append(entries, managedEntry{target: target, name: skill, dest: dest})
+		}
+	}
+	return entries, nil
+}
+
+func filterTargetTypes(targets []installer.Target, types []string) ([]installer.Target, error) {
+	wanted := make(map[installer.TargetType]bool, len(types))
+	for _, value := range types {
+		if !installer.IsTargetType(value) {
+			return nil, fmt.Errorf("invalid target %q: want one of %s", value, joinTargetTypes())
+		}
+		wanted[installer.TargetType(value)] = true
+	}
+	var filtered []installer.Target
+	for _, target := range targets {
+		if wanted[target.Type] {
+			filtered = append(filtered, target)
+		}
+	}
+	return filtered, nil
+}
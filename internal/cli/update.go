@@ -0,0 +1,217 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"agent-skills/internal/installer"
+)
+
+type updateCounts struct {
+	refreshed int
+	current   int
+	linked    int
+	skipped   int
+}
+
+func runUpdateCommand(args []string, cmdName string) error {
+	fs := flag.NewFlagSet(cmdName+" update", flag.ContinueOnError)
+	fs.SetOutput(os.Stderr)
+	var projectPath string
+	var targetList string
+	var dryRun bool
+	var noPull bool
+	fs.StringVar(&projectPath, "project", "", "also update project-local targets under this path")
+	fs.StringVar(&projectPath, "p", "", "alias for --project")
+	fs.StringVar(&targetList, "target", "", "comma-separated target types to update (defaults to all)")
+	fs.BoolVar(&dryRun, "dry-run", false, "report what would be refreshed without changing anything")
+	fs.BoolVar(&noPull, "no-pull", false, "do not pull cached repos before refreshing installs")
+	fs.Usage = func() {
+		out := fs.Output()
+		fmt.Fprintf(out, "Usage: %s update [options]\n\n", cmdName)
+		fmt.Fprintln(out, "Pull cached skills repos and re-copy managed copy and hardlink installs from their source.")
+		fmt.Fprintln(out)
+		fmt.Fprintln(out, "Options:")
+		tw := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(tw, "  -p, --project\tAlso update project-local targets under this path")
+		fmt.Fprintf(tw, "  --target\tComma-separated target types (%s)\n", joinTargetTypes())
+		fmt.Fprintln(tw, "  --dry-run\tReport what would be refreshed without changing anything")
+		fmt.Fprintln(tw, "  --no-pull\tDo not pull cached repos before refreshing installs")
+		fmt.Fprintln(tw, "  -h, --help\tShow help")
+		_ = tw.Flush()
+	}
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return fmt.Errorf("determine home directory: %w", err)
+	}
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	targets, err := discoverTargets(homeDir, projectPath, cfg, true)
+	if err != nil {
+		return err
+	}
+	if targetList != "" {
+		targets, err = filterTargetTypes(targets, splitList(targetList))
+		if err != nil {
+			return err
+		}
+	}
+	entries, err := managedEntries(targets)
+	if err != nil {
+		return err
+	}
+	if len(entries) == 0 {
+		fmt.Println("No managed skills to update")
+		return nil
+	}
+
+	ctx := installer.WithLogger(context.Background(), debugf)
+	report := &reporter{}
+	if !noPull {
+		clone := cloneOptions{}.withEnvToken()
+		for _, dir := range cachedSourceRepos(entries) {
+			if err := pullCachedRepo(ctx, clone, dir, dryRun); err != nil {
+				report.warnf("pull %s: %v", dir, clone.redactError(err))
+			}
+		}
+	}
+
+	var counts updateCounts
+	for _, entry := range entries {
+		meta, err := installer.ReadMeta(entry.dest)
+		if entry.name == installer.AllSkills || err == nil && meta.Mode == installer.ModeSymlink {
+			counts.linked++
+			fmt.Printf("%s in %s is a symlink; always current\n", entry.name, entry.target.Label)
+			continue
+		}
+		if err != nil {
+			counts.skipped++
+			report.warnf("%s: cannot read %s; skipping", entry.dest, installer.MetaPath(entry.dest))
+			continue
+		}
+		src := meta.Source
+		if meta.Layout == installer.LayoutFlat && !installer.IsFileSkill(src) {
+			src = filepath.Join(src, "SKILL.md")
+		}
+		if _, err := os.Stat(src); err != nil {
+			counts.skipped++
+			report.warnf("%s in %s: source %s no longer exists; skipping", entry.name, entry.target.Label, meta.Source)
+			continue
+		}
+		if installer.CheckInstalled(src, entry.dest, meta.Mode, meta.Layout) == installer.StateCurrent {
+			counts.current++
+			continue
+		}
+		counts.refreshed++
+		if dryRun {
+			fmt.Printf("Would refresh %s in %s\n", entry.name, entry.target.Label)
+			continue
+		}
+		if err := refreshInstall(ctx, src, entry.dest, meta); err != nil {
+			return fmt.Errorf("refresh %s in %s: %w", entry.name, entry.target.Label, err)
+		}
+		fmt.Printf("Refreshed %s in %s (%s)\n", entry.name, entry.target.Label, meta.Mode)
+	}
+
+	verb := "refreshed"
+	if dryRun {
+		verb = "to refresh"
+	}
+	fmt.Printf("Summary: %d %s, %d already current, %d symlinked, %d skipped\n", counts.refreshed, verb, counts.current, counts.linked, counts.skipped)
+	return nil
+}
+
+func refreshInstall(ctx context.Context, src, dest string, meta installer.Meta) error {
+	var err error
+	switch {
+	case meta.Layout == installer.LayoutFlat:
+		err = installer.InstallSkillFile(src, dest, meta.Mode)
+	case meta.Merged:
+		err = installer.MergeSkillContext(ctx, src, dest)
+	default:
+		err = installer.InstallSkillContext(ctx, src, dest, meta.Mode)
+	}
+	if err == nil {
+		err = installer.VerifyInstall(dest, meta.Layout)
+	}
+	if err != nil {
+		return err
+	}
+	skillFile := src
+	if meta.Layout != installer.LayoutFlat {
+		skillFile = filepath.Join(src, "SKILL.md")
+	}
+	if info, err := installer.InspectFrontmatter(skillFile); err == nil {
+		meta.Version = info.Version
+	}
+	return installer.WriteMeta(dest, meta)
+}
+
+func cachedSourceRepos(entries []managedEntry) []string {
+	root, err := repoCacheRoot()
+	if err != nil {
+		return nil
+	}
+	seen := make(map[string]bool)
+	var dirs []string
+	for _, entry := range entries {
+		source := entry.dest
+		if meta, err := installer.ReadMeta(entry.dest); err == nil && meta.Source != "" {
+			source = meta.Source
+		}
+		rel, err := filepath.Rel(root, source)
+		if err != nil || rel == "." || strings.HasPrefix(rel, "..") {
+			continue
+		}
+		first, _, _ := strings.Cut(filepath.ToSlash(rel), "/")
+		dir := filepath.Join(root, first)
+		if !seen[dir] {
+			seen[dir] = true
+			dirs = append(dirs, dir)
+		}
+	}
+	sort.Strings(dirs)
+	return dirs
+}
+
+func pullCachedRepo(ctx context.Context, clone cloneOptions, dir string, dryRun bool) error {
+	if !isGitToplevel(ctx, dir) {
+		return nil
+	}
+	origin, _ := gitOutput(ctx, dir, "remote", "get-url", "origin")
+	branch, err := gitOutput(ctx, dir, "symbolic-ref", "-q", "--short", "HEAD")
+	if err != nil {
+		fmt.Printf("%s is pinned to a tag or commit; not pulled\n", origin)
+		return nil
+	}
+	if dryRun {
+		fmt.Printf("Would pull %s (%s)\n", origin, branch)
+		return nil
+	}
+	before, _ := gitOutput(ctx, dir, "rev-parse", "HEAD")
+	if err := refreshCache(ctx, clone.forRepo(origin), dir, branch); err != nil {
+		return err
+	}
+	if after, _ := gitOutput(ctx, dir, "rev-parse", "HEAD"); after == before {
+		fmt.Printf("%s is up to date\n", origin)
+	} else {
+		fmt.Printf("Pulled %s (%s)\n", origin, branch)
+	}
+	return nil
+}
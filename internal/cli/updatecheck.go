@@ -0,0 +1,107 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	updateCheckInterval = 24 * time.Hour
+	updateCheckTimeout  = 5 * time.Second
+	releaseOwner        = "mbtz"
+	releaseRepo         = "agent-skills"
+)
+
+const (
+	updateSourceBrew   = "brew"
+	updateSourceGitHub = "github"
+)
+
+type versionCache struct {
+	Latest    string    `json:"latest"`
+	Source    string    `json:"source,omitempty"`
+	CheckedAt time.Time `json:"checked_at"`
+}
+
+func versionCachePath() (string, error) {
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(cacheDir, "askill", "version.json"), nil
+}
+
+func readVersionCache(path string) (versionCache, bool) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return versionCache{}, false
+	}
+	var cache versionCache
+	if err := json.Unmarshal(data, &cache); err != nil {
+		return versionCache{}, false
+	}
+	age := time.Since(cache.CheckedAt)
+	return cache, age >= 0 && age < updateCheckInterval
+}
+
+func writeVersionCache(path string, cache versionCache) error {
+	data, err := json.MarshalIndent(cache, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+func latestVersion() (string, string) {
+	path, pathErr := versionCachePath()
+	if pathErr == nil {
+		if cache, fresh := readVersionCache(path); fresh {
+			return cache.Latest, cache.Source
+		}
+	}
+	latest, source := queryLatestVersion()
+	if pathErr == nil {
+		_ = writeVersionCache(path, versionCache{Latest: latest, Source: source, CheckedAt: time.Now()})
+	}
+	return latest, source
+}
+
+func queryLatestVersion() (string, string) {
+	if _, err := exec.LookPath("brew"); err == nil {
+		if latest, err := brewLatestVersion(); err == nil && latest != "" {
+			return latest, updateSourceBrew
+		}
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), updateCheckTimeout)
+	defer cancel()
+	if latest, err := githubLatestVersion(ctx, releaseOwner, releaseRepo); err == nil && latest != "" {
+		return latest, updateSourceGitHub
+	}
+	return "", ""
+}
+
+func brewLatestVersion() (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), updateCheckTimeout)
+	defer cancel()
+	latest, err := brewLivecheckVersion(ctx)
+	if err != nil || latest == "" {
+		latest, err = brewStableVersion(ctx)
+	}
+	return latest, err
+}
+
+func githubLatestVersion(ctx context.Context, owner, repo string) (string, error) {
+	release, err := githubLatestRelease(ctx, cloneOptions{}, owner, repo)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimPrefix(strings.TrimSpace(release.TagName), "v"), nil
+}
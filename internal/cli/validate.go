@@ -0,0 +1,155 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+	"unicode/utf8"
+
+	"agent-skills/internal/installer"
+)
+
+const maxDescriptionLength = 1024
+
+func runValidateCommand(args []string, cmdName string) (err error) {
+	fs := flag.NewFlagSet(cmdName+" validate", flag.ContinueOnError)
+	fs.SetOutput(os.Stderr)
+	var strict bool
+	fs.BoolVar(&strict, "strict", false, "exit non-zero if any warning was emitted")
+	fs.Usage = func() {
+		out := fs.Output()
+		fmt.Fprintf(out, "Usage: %s validate [--strict] [path]\n\n", cmdName)
+		fmt.Fprintln(out, "Check SKILL.md frontmatter in a skills repo (defaults to the configured repo); exits non-zero on errors.")
+		fmt.Fprintln(out)
+		fmt.Fprintln(out, "Options:")
+		tw := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(tw, "  --strict\tExit non-zero if any warning was emitted")
+		fmt.Fprintln(tw, "  -h, --help\tShow help")
+		_ = tw.Flush()
+	}
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+	if fs.NArg() > 1 {
+		return errors.New("validate takes at most one path")
+	}
+
+	report := &reporter{}
+	if strict {
+		defer func() {
+			if err == nil {
+				err = report.strictError()
+			}
+		}()
+	}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	root, cleanup, err := resolveRepoRoot(context.Background(), cloneOptions{}, fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	if cleanup != nil {
+		defer cleanup()
+	}
+	skillsRoot := filepath.Join(root, "skills")
+	if !installer.ExistsDir(skillsRoot) {
+		skillsRoot = root
+	}
+	skills, err := installer.DiscoverSkillsWithDescriptionFile(skillsRoot, descriptionFile(cfg))
+	if err != nil {
+		return fmt.Errorf("discover skills: %w", err)
+	}
+
+	var problems []string
+	errorf := func(format string, args ...any) {
+		msg := fmt.Sprintf(format, args...)
+		problems = append(problems, msg)
+		fmt.Fprintf(os.Stderr, "error: %s\n", msg)
+	}
+	byName := make(map[string]string, len(skills))
+	for _, skill := range skills {
+		path := filepath.Join(skill.Path, "SKILL.md")
+		key := strings.ToLower(skill.Name)
+		if first, ok := byName[key]; ok {
+			errorf("%s: duplicate skill name '%s', also used by %s", path, skill.Name, first)
+		} else {
+			byName[key] = path
+		}
+		info, err := installer.InspectFrontmatter(path)
+		if err != nil {
+			return fmt.Errorf("read %s: %w", path, err)
+		}
+		if !info.Fenced {
+			errorf("%s: missing '---' frontmatter fences", path)
+			continue
+		}
+		if info.Name == "" {
+			errorf("%s: missing 'name'", path)
+		}
+		if skill.Description == "" {
+			errorf("%s: missing 'description'", path)
+		} else if length := utf8.RuneCountInString(skill.Description); length > maxDescriptionLength {
+			report.warnf("%s: description is %d characters, over the limit of %d", path, length, maxDescriptionLength)
+		}
+		keys, err := installer.FrontmatterKeys(path)
+		if err != nil {
+			return fmt.Errorf("read %s: %w", path, err)
+		}
+		for _, key := range keys {
+			if suggestion, ok := suggestFrontmatterKey(key); ok {
+				report.warnf("%s: unknown key '%s', did you mean '%s'?", path, key, suggestion)
+			}
+		}
+	}
+	fmt.Printf("%d skill(s) checked, %d error(s), %d warning(s)\n", len(skills), len(problems), len(report.warnings))
+	if len(problems) > 0 {
+		return fmt.Errorf("%d error(s) in skill frontmatter", len(problems))
+	}
+	return nil
+}
+
+func suggestFrontmatterKey(key string) (string, bool) {
+	best := ""
+	bestDistance := 3
+	for _, known := range installer.KnownFrontmatterKeys {
+		if key == known {
+			return "", false
+		}
+		if distance := levenshtein(key, known); distance < bestDistance {
+			best, bestDistance = known, distance
+		}
+	}
+	return best, best != ""
+}
+
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr := make([]int, len(rb)+1)
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev = curr
+	}
+	return prev[len(rb)]
+}
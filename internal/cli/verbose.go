@@ -0,0 +1,29 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+)
+
+var verboseOut io.Writer = io.Discard
+
+func enableVerbose(args []string) []string {
+	kept := make([]string, 0, len(args))
+	for i, arg := range args {
+		if arg == "--" {
+			return append(kept, args[i:]...)
+		}
+		switch arg {
+		case "-V", "--V", "-verbose", "--verbose":
+			verboseOut = os.Stderr
+		default:
+			kept = append(kept, arg)
+		}
+	}
+	return kept
+}
+
+func debugf(format string, args ...any) {
+	fmt.Fprintf(verboseOut, "verbose: "+format+"\n", args...)
+}
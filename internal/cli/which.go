@@ -0,0 +1,180 @@
+package cli
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"agent-skills/internal/installer"
+)
+
+const (
+	versionBehind  = "behind"
+	versionCurrent = "current"
+	versionAhead   = "ahead"
+	versionUnknown = "unknown"
+)
+
+type skillLocation struct {
+	Target           installer.TargetType `json:"target"`
+	Path             string               `json:"path"`
+	Mode             installer.Mode       `json:"mode"`
+	InstalledVersion string               `json:"installed_version"`
+	SourceVersion    string               `json:"source_version"`
+	Status           string               `json:"status"`
+}
+
+func runWhichCommand(args []string, cmdName string) error {
+	fs := flag.NewFlagSet(cmdName+" which", flag.ContinueOnError)
+	fs.SetOutput(os.Stderr)
+	var repoRoot string
+	var projectPath string
+	var jsonOutput bool
+	fs.StringVar(&repoRoot, "repo", "", "path to skills repo, a .zip of one, or a git URL")
+	fs.StringVar(&repoRoot, "r", "", "alias for --repo")
+	fs.StringVar(&projectPath, "project", "", "also check project-local targets under this path")
+	fs.StringVar(&projectPath, "p", "", "alias for --project")
+	fs.BoolVar(&jsonOutput, "json", false, "print a JSON array instead of a table")
+	fs.Usage = func() {
+		out := fs.Output()
+		fmt.Fprintf(out, "Usage: %s which [options] SKILL\n\n", cmdName)
+		fmt.Fprintln(out, "Show where a skill is installed and whether each install is behind the source version.")
+		fmt.Fprintln(out)
+		fmt.Fprintln(out, "Options:")
+		tw := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(tw, "  -r, --repo\tPath to skills repo, a .zip of one, or a git URL (defaults to config)")
+		fmt.Fprintln(tw, "  -p, --project\tAlso check project-local targets under this path")
+		fmt.Fprintln(tw, "  --json\tPrint a JSON array instead of a table")
+		fmt.Fprintln(tw, "  -h, --help\tShow help")
+		_ = tw.Flush()
+	}
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return errors.New("which takes exactly one skill name")
+	}
+	name := fs.Arg(0)
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	skills, cleanup, err := listSkills(repoRoot, cfg)
+	if err != nil {
+		return err
+	}
+	if cleanup != nil {
+		defer cleanup()
+	}
+	var skill installer.Skill
+	found := false
+	for _, candidate := range skills {
+		if skillMatches(candidate, name) {
+			skill, found = candidate, true
+			break
+		}
+	}
+	if !found {
+		return fmt.Errorf("no skill named %s in the skills repo", name)
+	}
+
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return fmt.Errorf("determine home directory: %w", err)
+	}
+	targets, err := discoverTargets(homeDir, projectPath, cfg, true)
+	if err != nil {
+		return err
+	}
+	locations := make([]skillLocation, 0, len(targets))
+	for _, target := range targets {
+		if location, ok := locateSkill(target, skill); ok {
+			locations = append(locations, location)
+		}
+	}
+
+	if jsonOutput {
+		return printJSON(locations)
+	}
+	if len(locations) == 0 {
+		fmt.Printf("%s is not installed in any target\n", skill.Name)
+		return nil
+	}
+	tw := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "TARGET\tPATH\tMODE\tINSTALLED\tSOURCE\tSTATUS")
+	for _, location := range locations {
+		status := location.Status
+		if status == versionBehind {
+			status = warningStyle.Render(status)
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n", location.Target, location.Path, location.Mode, versionLabel(location.InstalledVersion), versionLabel(location.SourceVersion), status)
+	}
+	return tw.Flush()
+}
+
+func locateSkill(target installer.Target, skill installer.Skill) (skillLocation, bool) {
+	location := skillLocation{Target: target.Type, SourceVersion: skill.Version}
+	if meta, ok := linkedRoot(target); ok {
+		dest := linkedSkillPath(target.Path, skill)
+		if _, err := os.Lstat(linkedSkillPath(meta.Source, skill)); err != nil {
+			return skillLocation{}, false
+		}
+		location.Path = dest
+		location.Mode = meta.Mode
+		location.InstalledVersion = skill.Version
+		location.Status = compareInstalledVersion(location.InstalledVersion, location.SourceVersion)
+		return location, true
+	}
+	for _, layout := range []installer.Layout{installer.LayoutDir, installer.LayoutFlat} {
+		dest := skillDestination(target, skill, layout, destOptions{})
+		info, err := os.Lstat(dest)
+		if err != nil {
+			continue
+		}
+		location.Path = dest
+		location.Mode = installer.ModeCopy
+		if info.Mode()&os.ModeSymlink != 0 {
+			location.Mode = installer.ModeSymlink
+		}
+		if meta, err := installer.ReadMeta(dest); err == nil {
+			if meta.Mode != "" {
+				location.Mode = meta.Mode
+			}
+			location.InstalledVersion = meta.Version
+		}
+		if location.Mode == installer.ModeSymlink {
+			location.InstalledVersion = skill.Version
+		}
+		location.Status = compareInstalledVersion(location.InstalledVersion, location.SourceVersion)
+		return location, true
+	}
+	return skillLocation{}, false
+}
+
+func compareInstalledVersion(installed, source string) string {
+	if installed == "" || source == "" {
+		return versionUnknown
+	}
+	switch compareVersions(installed, source) {
+	case -1:
+		return versionBehind
+	case 1:
+		return versionAhead
+	default:
+		return versionCurrent
+	}
+}
+
+func versionLabel(version string) string {
+	if version == "" {
+		return "-"
+	}
+	return version
+}
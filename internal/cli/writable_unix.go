@@ -0,0 +1,9 @@
+//go:build !windows
+
+package cli
+
+import "golang.org/x/sys/unix"
+
+func dirWritable(dir string) bool {
+	return unix.Access(dir, unix.W_OK) == nil
+}
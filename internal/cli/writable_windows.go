@@ -0,0 +1,8 @@
+package cli
+
+import "os"
+
+func dirWritable(dir string) bool {
+	info, err := os.Stat(dir)
+	return err == nil && info.Mode().Perm()&0o200 != 0
+}
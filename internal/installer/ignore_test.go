@@ -0,0 +1,131 @@
+package installer
+
+import (
+	"archive/zip"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestParseIgnoreLine(t *testing.T) {
+	tests := []struct {
+		line string
+		want ignoreRule
+		ok   bool
+	}{
+		{"", ignoreRule{}, false},
+		{"# comment", ignoreRule{}, false},
+		{"   ", ignoreRule{}, false},
+		{"templates", ignoreRule{segments: []string{"templates"}}, true},
+		{"templates/", ignoreRule{segments: []string{"templates"}, dirOnly: true}, true},
+		{"/drafts", ignoreRule{segments: []string{"drafts"}, anchored: true}, true},
+		{"cat/wip", ignoreRule{segments: []string{"cat", "wip"}, anchored: true}, true},
+		{"**/wip", ignoreRule{segments: []string{"**", "wip"}, anchored: true}, true},
+		{"!example-basic", ignoreRule{segments: []string{"example-basic"}, negate: true}, true},
+		{`\#literal`, ignoreRule{segments: []string{"#literal"}}, true},
+		{"trailing  ", ignoreRule{segments: []string{"trailing"}}, true},
+	}
+	for _, tt := range tests {
+		got, ok := parseIgnoreLine(tt.line)
+		if ok != tt.ok || !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("parseIgnoreLine(%q) = %+v, %v; want %+v, %v", tt.line, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestIgnoreRulesIgnored(t *testing.T) {
+	rules := ignoreRules{}
+	for _, line := range []string{"templates/", "example-*", "!example-basic", "/top", "cat/draft", "**/wip", "notes.md"} {
+		rule, ok := parseIgnoreLine(line)
+		if !ok {
+			t.Fatalf("parseIgnoreLine(%q) rejected", line)
+		}
+		rules = append(rules, rule)
+	}
+	tests := []struct {
+		rel   string
+		isDir bool
+		want  bool
+	}{
+		{"templates", true, true},
+		{"nested/templates", true, true},
+		{"templates", false, false},
+		{"example-a", true, true},
+		{"cat/example-b", true, true},
+		{"example-basic", true, false},
+		{"top", true, true},
+		{"cat/top", true, false},
+		{"cat/draft", true, true},
+		{"other/cat/draft", true, false},
+		{"wip", true, true},
+		{"a/b/wip", true, true},
+		{"notes.md", false, true},
+		{"real", true, false},
+	}
+	for _, tt := range tests {
+		if got := rules.ignored(tt.rel, tt.isDir); got != tt.want {
+			t.Errorf("ignored(%q, dir=%v) = %v, want %v", tt.rel, tt.isDir, got, tt.want)
+		}
+	}
+}
+
+func TestDiscoverSkillsHonorsIgnoreFile(t *testing.T) {
+	root := t.TempDir()
+	for _, dir := range []string{"real", "templates/base", "example-a", "example-basic", "cat/draft", "cat/ok"} {
+		writeFile(t, filepath.Join(root, filepath.FromSlash(dir), "SKILL.md"), "---\nname: "+filepath.Base(dir)+"\n---\n")
+	}
+	if err := os.WriteFile(filepath.Join(root, IgnoreFile), []byte("templates/\nexample-*\n!example-basic\n/cat/draft\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	skills, err := DiscoverSkills(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, skill := range skills {
+		names = append(names, skill.Name)
+	}
+	sort.Strings(names)
+	if want := []string{"example-basic", "ok", "real"}; !reflect.DeepEqual(names, want) {
+		t.Fatalf("discovered %v, want %v", names, want)
+	}
+}
+
+func TestDiscoverZipSkillsHonorsIgnoreFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "skills.zip")
+	file, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	zw := zip.NewWriter(file)
+	entries := map[string]string{
+		"skills/.askillignore":            "templates/\n",
+		"skills/real/SKILL.md":            "---\nname: real\n---\n",
+		"skills/templates/base/SKILL.md":  "---\nname: base\n---\n",
+		"skills/cat/templates/x/SKILL.md": "---\nname: x\n---\n",
+	}
+	for name, body := range entries {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write([]byte(body)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := file.Close(); err != nil {
+		t.Fatal(err)
+	}
+	skills, err := DiscoverZipSkills(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(skills) != 1 || skills[0].Name != "real" {
+		t.Fatalf("discovered %+v, want only real", skills)
+	}
+}
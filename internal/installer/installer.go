@@ -2,36 +2,56 @@ package installer
 
 import (
 	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"io"
 	"io/fs"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"syscall"
+
+	"gopkg.in/yaml.v3"
 )
 
 type Mode string
 
 const (
-	ModeSymlink Mode = "symlink"
-	ModeCopy    Mode = "copy"
+	ModeSymlink  Mode = "symlink"
+	ModeCopy     Mode = "copy"
+	ModeHardlink Mode = "hardlink"
 )
 
 type Skill struct {
 	Name        string
 	Description string
 	Path        string
+	Enabled     bool
+	Priority    int
+	DependsOn   []string
+	Aliases     []string
+	Tags        []string
+	Category    string
+	Version     string
+	Namespace   string
 }
 
 type TargetType string
 
 const (
-	TargetCodexGlobal   TargetType = "codex-global"
-	TargetClaudeGlobal  TargetType = "claude-global"
-	TargetClaudeProject TargetType = "claude-project"
-	TargetCursorGlobal  TargetType = "cursor-global"
-	TargetCursorProject TargetType = "cursor-project"
+	TargetCodexGlobal     TargetType = "codex-global"
+	TargetClaudeGlobal    TargetType = "claude-global"
+	TargetClaudeProject   TargetType = "claude-project"
+	TargetCursorGlobal    TargetType = "cursor-global"
+	TargetCursorProject   TargetType = "cursor-project"
+	TargetWindsurfGlobal  TargetType = "windsurf-global"
+	TargetWindsurfProject TargetType = "windsurf-project"
+	TargetZedGlobal       TargetType = "zed-global"
+	TargetZedProject      TargetType = "zed-project"
 )
 
 type Target struct {
@@ -41,7 +61,15 @@ type Target struct {
 	Exists bool
 }
 
+const DefaultDescriptionFile = "DESCRIPTION.txt"
+
+const maxDescriptionLen = 200
+
 func DiscoverSkills(skillsRoot string) ([]Skill, error) {
+	return DiscoverSkillsWithDescriptionFile(skillsRoot, DefaultDescriptionFile)
+}
+
+func DiscoverSkillsWithDescriptionFile(skillsRoot, descriptionFile string) ([]Skill, error) {
 	rootInfo, err := os.Stat(skillsRoot)
 	if err != nil {
 		return nil, fmt.Errorf("skills root not found: %w", err)
@@ -50,6 +78,10 @@ func DiscoverSkills(skillsRoot string) ([]Skill, error) {
 		return nil, fmt.Errorf("skills root is not a directory: %s", skillsRoot)
 	}
 
+	ignore, err := loadIgnoreRules(skillsRoot)
+	if err != nil {
+		return nil, err
+	}
 	var skills []Skill
 	err = filepath.WalkDir(skillsRoot, func(path string, d fs.DirEntry, walkErr error) error {
 		if walkErr != nil {
@@ -58,22 +90,41 @@ func DiscoverSkills(skillsRoot string) ([]Skill, error) {
 		if !d.IsDir() {
 			return nil
 		}
+		if rel, err := filepath.Rel(skillsRoot, path); err == nil && rel != "." && ignore.ignored(filepath.ToSlash(rel), true) {
+			return fs.SkipDir
+		}
 		skillFile := filepath.Join(path, "SKILL.md")
 		info, err := os.Stat(skillFile)
 		if err != nil || info.IsDir() {
 			return nil
 		}
-		name, desc, err := parseSkillFrontmatter(skillFile)
+		meta, err := parseSkillFrontmatter(skillFile)
 		if err != nil {
 			return fmt.Errorf("parse %s: %w", skillFile, err)
 		}
+		name := meta.name
 		if name == "" {
 			name = filepath.Base(path)
 		}
+		if meta.description == "" && descriptionFile != "" {
+			meta.description = readDescriptionFile(filepath.Join(path, descriptionFile))
+		}
+		namespace := ""
+		if rel, err := filepath.Rel(skillsRoot, filepath.Dir(path)); err == nil && path != skillsRoot && rel != "." {
+			namespace = filepath.ToSlash(rel)
+		}
 		skills = append(skills, Skill{
 			Name:        name,
-			Description: desc,
+			Description: meta.description,
 			Path:        path,
+			Enabled:     meta.enabled,
+			Priority:    meta.priority,
+			DependsOn:   meta.dependsOn,
+			Aliases:     meta.aliases,
+			Tags:        meta.tags,
+			Category:    meta.category,
+			Version:     meta.version,
+			Namespace:   namespace,
 		})
 		return fs.SkipDir
 	})
@@ -86,86 +137,805 @@ func DiscoverSkills(skillsRoot string) ([]Skill, error) {
 	return skills, nil
 }
 
-func DiscoverTargets(homeDir, projectPath string) []Target {
-	var targets []Target
+func InstallName(skill Skill) string {
+	base := filepath.Base(skill.Path)
+	if skill.Namespace == "" {
+		return base
+	}
+	return strings.ReplaceAll(skill.Namespace, "/", "-") + "-" + base
+}
 
-	codexPath := filepath.Join(homeDir, ".codex", "skills")
-	if existsDir(codexPath) {
-		targets = append(targets, Target{
-			Type:   TargetCodexGlobal,
-			Label:  "Codex CLI (global)",
-			Path:   codexPath,
-			Exists: true,
-		})
+type DuplicateSkill struct {
+	Name     string
+	Kept     string
+	Replaced string
+}
+
+func MergeSkills(groups ...[]Skill) ([]Skill, []DuplicateSkill) {
+	var merged []Skill
+	var duplicates []DuplicateSkill
+	owner := make(map[string]int)
+	index := make(map[string]int)
+	for g, group := range groups {
+		for _, skill := range group {
+			if prev, ok := owner[skill.Name]; ok && prev != g {
+				i := index[skill.Name]
+				if sameSkillContent(merged[i].Path, skill.Path) {
+					continue
+				}
+				duplicates = append(duplicates, DuplicateSkill{Name: skill.Name, Kept: skill.Path, Replaced: merged[i].Path})
+				merged[i] = skill
+				owner[skill.Name] = g
+				continue
+			}
+			owner[skill.Name] = g
+			index[skill.Name] = len(merged)
+			merged = append(merged, skill)
+		}
 	}
+	return merged, duplicates
+}
 
-	claudeGlobal := filepath.Join(homeDir, ".claude", "skills")
-	if existsDir(claudeGlobal) {
-		targets = append(targets, Target{
-			Type:   TargetClaudeGlobal,
-			Label:  "Claude Code (global)",
-			Path:   claudeGlobal,
-			Exists: true,
-		})
+func sameSkillContent(a, b string) bool {
+	hashA, err := hashTree(a)
+	if err != nil {
+		return false
 	}
+	hashB, err := hashTree(b)
+	if err != nil {
+		return false
+	}
+	return hashA == hashB
+}
 
-	if projectPath != "" {
-		claudeProject := filepath.Join(projectPath, ".claude", "skills")
-		targets = append(targets, Target{
-			Type:   TargetClaudeProject,
-			Label:  "Claude Code (project)",
-			Path:   claudeProject,
-			Exists: existsDir(claudeProject),
-		})
+func hashTree(root string) (string, error) {
+	h := sha256.New()
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, walkErr error) error {
+		if walkErr != nil {
+			return walkErr
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(h, "%s\x00%s\x00", filepath.ToSlash(rel), d.Type())
+		switch {
+		case d.Type()&os.ModeSymlink != 0:
+			link, err := os.Readlink(path)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(h, "%s\x00", link)
+		case d.Type().IsRegular():
+			file, err := os.Open(path)
+			if err != nil {
+				return err
+			}
+			defer file.Close()
+			if _, err := io.Copy(h, file); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
 
-		cursorProject := filepath.Join(projectPath, ".cursor", "skills")
-		targets = append(targets, Target{
-			Type:   TargetCursorProject,
-			Label:  "Cursor (project)",
-			Path:   cursorProject,
-			Exists: existsDir(cursorProject),
-		})
+func DetectSkillsDir(root string) (string, error) {
+	conventional := filepath.Join(root, "skills")
+	if existsDir(conventional) {
+		return conventional, nil
+	}
+	if hasSkillSubdirs(root) {
+		return root, nil
+	}
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		return "", fmt.Errorf("skills root not found: %w", err)
 	}
+	for _, entry := range entries {
+		if !entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		candidate := filepath.Join(root, entry.Name())
+		if hasSkillSubdirs(candidate) {
+			return candidate, nil
+		}
+	}
+	return "", fmt.Errorf("no directory with skills found under %s", root)
+}
 
-	cursorGlobal := filepath.Join(homeDir, ".cursor", "skills")
-	if existsDir(cursorGlobal) {
-		targets = append(targets, Target{
-			Type:   TargetCursorGlobal,
-			Label:  "Cursor (global)",
-			Path:   cursorGlobal,
-			Exists: true,
-		})
+func hasSkillSubdirs(dir string) bool {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return false
 	}
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		info, err := os.Stat(filepath.Join(dir, entry.Name(), "SKILL.md"))
+		if err == nil && !info.IsDir() {
+			return true
+		}
+	}
+	return false
+}
+
+type targetSpec struct {
+	Type    TargetType
+	Label   string
+	Subdir  string
+	Project bool
+}
+
+var targetSpecs = []targetSpec{
+	{Type: TargetCodexGlobal, Label: "Codex CLI (global)", Subdir: ".codex/skills"},
+	{Type: TargetClaudeGlobal, Label: "Claude Code (global)", Subdir: ".claude/skills"},
+	{Type: TargetClaudeProject, Label: "Claude Code (project)", Subdir: ".claude/skills", Project: true},
+	{Type: TargetCursorProject, Label: "Cursor (project)", Subdir: ".cursor/skills", Project: true},
+	{Type: TargetCursorGlobal, Label: "Cursor (global)", Subdir: ".cursor/skills"},
+	{Type: TargetWindsurfProject, Label: "Windsurf (project)", Subdir: ".windsurf/skills", Project: true},
+	{Type: TargetWindsurfGlobal, Label: "Windsurf (global)", Subdir: ".windsurf/skills"},
+	{Type: TargetZedProject, Label: "Zed (project)", Subdir: ".zed/skills", Project: true},
+	{Type: TargetZedGlobal, Label: "Zed (global)", Subdir: ".config/zed/skills"},
+}
+
+func TargetTypes() []TargetType {
+	types := make([]TargetType, 0, len(targetSpecs))
+	for _, spec := range targetSpecs {
+		types = append(types, spec.Type)
+	}
+	return types
+}
+
+func IsTargetType(value string) bool {
+	for _, spec := range targetSpecs {
+		if string(spec.Type) == value {
+			return true
+		}
+	}
+	return false
+}
+
+func TargetForType(t TargetType, homeDir, projectPath string) (Target, bool) {
+	return targetForType(t, homeDir, projectPath, nil)
+}
 
+func TargetForTypeWithSubdirs(t TargetType, homeDir, projectPath string, subdirs map[TargetType]string) (Target, bool) {
+	return targetForType(t, homeDir, projectPath, subdirs)
+}
+
+func targetForType(t TargetType, homeDir, projectPath string, subdirs map[TargetType]string) (Target, bool) {
+	for _, spec := range targetSpecs {
+		if spec.Type != t {
+			continue
+		}
+		base := homeDir
+		if spec.Project {
+			if projectPath == "" {
+				return Target{}, false
+			}
+			base = projectPath
+		}
+		subdir := spec.Subdir
+		if override := subdirs[t]; override != "" {
+			subdir = override
+		}
+		path := filepath.Join(base, filepath.FromSlash(subdir))
+		return Target{
+			Type:   spec.Type,
+			Label:  spec.Label,
+			Path:   path,
+			Exists: existsDir(path),
+		}, true
+	}
+	return Target{}, false
+}
+
+func IsProjectTarget(t TargetType) bool {
+	for _, spec := range targetSpecs {
+		if spec.Type == t {
+			return spec.Project
+		}
+	}
+	return false
+}
+
+func DiscoverTargets(homeDir, projectPath string) []Target {
+	return DiscoverTargetsWithSubdirs(homeDir, projectPath, nil)
+}
+
+func DiscoverTargetsWithSubdirs(homeDir, projectPath string, subdirs map[TargetType]string) []Target {
+	var targets []Target
+	seen := make(map[string]bool)
+	for _, spec := range targetSpecs {
+		target, ok := targetForType(spec.Type, homeDir, projectPath, subdirs)
+		if !ok {
+			continue
+		}
+		if !target.Exists && !spec.Project {
+			continue
+		}
+		key := absPath(target.Path)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		targets = append(targets, target)
+	}
 	return targets
 }
 
+func DedupeTargetsByRealPath(targets []Target) []Target {
+	deduped := make([]Target, 0, len(targets))
+	seen := make(map[string]bool)
+	for _, target := range targets {
+		key := realPath(target.Path)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		deduped = append(deduped, target)
+	}
+	return deduped
+}
+
+func realPath(path string) string {
+	path = absPath(path)
+	var rest []string
+	for dir := path; ; dir = filepath.Dir(dir) {
+		if resolved, err := filepath.EvalSymlinks(dir); err == nil {
+			return filepath.Join(append([]string{resolved}, rest...)...)
+		}
+		if filepath.Dir(dir) == dir {
+			return path
+		}
+		rest = append([]string{filepath.Base(dir)}, rest...)
+	}
+}
+
+func absPath(path string) string {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return filepath.Clean(path)
+	}
+	return abs
+}
+
 func InstallSkill(srcDir, destDir string, mode Mode) error {
+	return InstallSkillContext(context.Background(), srcDir, destDir, mode)
+}
+
+func InstallSkillContext(ctx context.Context, srcDir, destDir string, mode Mode) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	destDir = filepath.Clean(destDir)
+	if err := CheckDistinct(srcDir, destDir); err != nil {
+		return err
+	}
+	logf(ctx, "install %s to %s (%s)", srcDir, destDir, mode)
+	switch mode {
+	case ModeSymlink:
+		return installSymlink(ctx, srcDir, destDir)
+	case ModeCopy:
+		return swapCopy(ctx, srcDir, destDir, progressFrom(ctx))
+	case ModeHardlink:
+		return installHardlink(ctx, srcDir, destDir)
+	default:
+		return fmt.Errorf("unknown install mode: %s", mode)
+	}
+}
+
+func InstallSkillFile(srcFile, destFile string, mode Mode) error {
+	destFile = filepath.Clean(destFile)
+	if err := CheckDistinct(srcFile, destFile); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(destFile), 0o755); err != nil {
+		return fmt.Errorf("create parent dir: %w", err)
+	}
+	if err := os.RemoveAll(destFile); err != nil {
+		return fmt.Errorf("remove existing target: %w", err)
+	}
 	switch mode {
 	case ModeSymlink:
-		return installSymlink(srcDir, destDir)
+		return os.Symlink(srcFile, destFile)
 	case ModeCopy:
-		return copyDir(srcDir, destDir)
+		info, err := os.Stat(srcFile)
+		if err != nil {
+			return err
+		}
+		return copyFile(srcFile, destFile, info.Mode(), nil)
+	case ModeHardlink:
+		return linkFile(srcFile, destFile)
 	default:
 		return fmt.Errorf("unknown install mode: %s", mode)
 	}
 }
 
-func installSymlink(srcDir, destDir string) error {
+type InstallState string
+
+const (
+	StateMissing InstallState = "missing"
+	StateCurrent InstallState = "current"
+	StateDrifted InstallState = "drifted"
+)
+
+func CheckInstalled(src, dest string, mode Mode, layout Layout) InstallState {
+	info, err := os.Lstat(dest)
+	if err != nil {
+		return StateMissing
+	}
+	if mode == ModeSymlink {
+		if info.Mode()&os.ModeSymlink == 0 {
+			return StateDrifted
+		}
+		resolvedDest, err := filepath.EvalSymlinks(dest)
+		if err != nil {
+			return StateDrifted
+		}
+		resolvedSrc, err := filepath.EvalSymlinks(src)
+		if err != nil || resolvedSrc != resolvedDest {
+			return StateDrifted
+		}
+		return StateCurrent
+	}
+	if info.Mode()&os.ModeSymlink != 0 {
+		return StateDrifted
+	}
+	if layout == LayoutFlat {
+		if sameFileContent(src, dest) {
+			return StateCurrent
+		}
+		return StateDrifted
+	}
+	if meta, err := ReadMeta(dest); err == nil && meta.Merged {
+		if sameTree(src, dest) {
+			return StateCurrent
+		}
+		return StateDrifted
+	}
+	if sameTree(src, dest) && sameTree(dest, src) {
+		return StateCurrent
+	}
+	return StateDrifted
+}
+
+func sameTree(a, b string) bool {
+	err := filepath.WalkDir(a, func(path string, d fs.DirEntry, walkErr error) error {
+		if walkErr != nil {
+			return walkErr
+		}
+		rel, err := filepath.Rel(a, path)
+		if err != nil {
+			return err
+		}
+		if rel == SourceStampFile {
+			return nil
+		}
+		other := filepath.Join(b, rel)
+		otherInfo, err := os.Lstat(other)
+		if err != nil {
+			return err
+		}
+		switch {
+		case d.Type()&os.ModeSymlink != 0:
+			if otherInfo.Mode()&os.ModeSymlink == 0 {
+				return errTreeMismatch
+			}
+			link, err := os.Readlink(path)
+			if err != nil {
+				return err
+			}
+			otherLink, err := os.Readlink(other)
+			if err != nil || link != otherLink {
+				return errTreeMismatch
+			}
+		case d.IsDir():
+			if !otherInfo.IsDir() {
+				return errTreeMismatch
+			}
+		default:
+			if !otherInfo.Mode().IsRegular() || !sameFileContent(path, other) {
+				return errTreeMismatch
+			}
+		}
+		return nil
+	})
+	return err == nil
+}
+
+var errTreeMismatch = errors.New("tree mismatch")
+
+var ErrSameLocation = errors.New("source and destination are the same")
+
+func CheckDistinct(src, dest string) error {
+	dest = filepath.Clean(dest)
+	resolvedSrc, err := filepath.EvalSymlinks(src)
+	if err != nil {
+		resolvedSrc = src
+	}
+	parent, err := filepath.EvalSymlinks(filepath.Dir(dest))
+	if err != nil {
+		parent = filepath.Dir(dest)
+	}
+	if absPath(resolvedSrc) == filepath.Join(absPath(parent), filepath.Base(dest)) {
+		return fmt.Errorf("%w: %s", ErrSameLocation, dest)
+	}
+	return nil
+}
+
+func VerifyInstall(dest string, layout Layout) error {
+	skillFile := filepath.Join(dest, "SKILL.md")
+	if layout == LayoutFlat {
+		skillFile = dest
+	}
+	info, err := os.Stat(skillFile)
+	if err != nil {
+		return fmt.Errorf("SKILL.md not reachable at %s: %w", dest, err)
+	}
+	if info.IsDir() {
+		return fmt.Errorf("SKILL.md at %s is a directory", dest)
+	}
+	file, err := os.Open(skillFile)
+	if err != nil {
+		return fmt.Errorf("SKILL.md not readable at %s: %w", dest, err)
+	}
+	return file.Close()
+}
+
+func DiscoverFileSkills(skillsRoot string) ([]Skill, error) {
+	entries, err := os.ReadDir(skillsRoot)
+	if err != nil {
+		return nil, fmt.Errorf("skills root not found: %w", err)
+	}
+	ignore, err := loadIgnoreRules(skillsRoot)
+	if err != nil {
+		return nil, err
+	}
+	var skills []Skill
+	for _, entry := range entries {
+		name := entry.Name()
+		if !entry.Type().IsRegular() || filepath.Ext(name) != ".md" || ignore.ignored(name, false) {
+			continue
+		}
+		path := filepath.Join(skillsRoot, name)
+		meta, err := parseSkillFrontmatter(path)
+		if err != nil {
+			return nil, fmt.Errorf("parse %s: %w", path, err)
+		}
+		if !meta.closed {
+			continue
+		}
+		if meta.name == "" {
+			meta.name = strings.TrimSuffix(name, ".md")
+		}
+		skills = append(skills, Skill{
+			Name:        meta.name,
+			Description: meta.description,
+			Path:        path,
+			Enabled:     meta.enabled,
+			Priority:    meta.priority,
+			DependsOn:   meta.dependsOn,
+			Aliases:     meta.aliases,
+			Tags:        meta.tags,
+			Category:    meta.category,
+			Version:     meta.version,
+		})
+	}
+	if len(skills) == 0 {
+		return nil, errors.New("no single-file skills found")
+	}
+	return skills, nil
+}
+
+func readDescriptionFile(path string) string {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		if runes := []rune(line); len(runes) > maxDescriptionLen {
+			line = string(runes[:maxDescriptionLen-3]) + "..."
+		}
+		return line
+	}
+	return ""
+}
+
+func IsFileSkill(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.Mode().IsRegular()
+}
+
+func IsSingleFileSkill(skillDir string) bool {
+	entries, err := os.ReadDir(skillDir)
+	if err != nil {
+		return false
+	}
+	return len(entries) == 1 && entries[0].Name() == "SKILL.md" && entries[0].Type().IsRegular()
+}
+
+func installSymlink(ctx context.Context, srcDir, destDir string) error {
+	if IsSymlinkTo(destDir, srcDir) {
+		logf(ctx, "%s already links to %s", destDir, srcDir)
+		return nil
+	}
 	if err := os.MkdirAll(filepath.Dir(destDir), 0o755); err != nil {
 		return fmt.Errorf("create parent dir: %w", err)
 	}
+	logf(ctx, "remove %s", destDir)
 	if err := os.RemoveAll(destDir); err != nil {
 		return fmt.Errorf("remove existing target: %w", err)
 	}
+	logf(ctx, "symlink %s -> %s", destDir, srcDir)
 	return os.Symlink(srcDir, destDir)
 }
 
-func copyDir(srcDir, destDir string) error {
+func IsSymlinkTo(dest, src string) bool {
+	link, err := os.Readlink(dest)
+	if err != nil {
+		return false
+	}
+	if !filepath.IsAbs(link) {
+		link = filepath.Join(filepath.Dir(dest), link)
+	}
+	return filepath.Clean(link) == absPath(src)
+}
+
+func swapCopy(ctx context.Context, srcDir, destDir string, progress ProgressFunc) error {
+	parent := filepath.Dir(destDir)
+	if err := os.MkdirAll(parent, 0o755); err != nil {
+		return fmt.Errorf("create parent dir: %w", err)
+	}
+	srcInfo, err := os.Stat(srcDir)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.MkdirTemp(parent, "."+filepath.Base(destDir)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	logf(ctx, "copy %s to %s", srcDir, tmp)
+	if err := copyDir(ctx, srcDir, tmp, progress); err != nil {
+		_ = os.RemoveAll(tmp)
+		return err
+	}
+	if err := os.Chmod(tmp, srcInfo.Mode().Perm()); err != nil {
+		_ = os.RemoveAll(tmp)
+		return err
+	}
+	return swapDir(ctx, tmp, destDir)
+}
+
+func swapDir(ctx context.Context, tmp, destDir string) error {
+	backup := ""
+	if _, err := os.Lstat(destDir); err == nil {
+		backup = tmp + "-old"
+		logf(ctx, "move existing %s aside to %s", destDir, backup)
+		if err := os.Rename(destDir, backup); err != nil {
+			_ = os.RemoveAll(tmp)
+			return fmt.Errorf("move existing target aside: %w", err)
+		}
+	}
+	logf(ctx, "rename %s to %s", tmp, destDir)
+	if err := renameDir(ctx, tmp, destDir); err != nil {
+		_ = os.RemoveAll(tmp)
+		if backup != "" {
+			_ = os.Rename(backup, destDir)
+		}
+		return err
+	}
+	if backup != "" {
+		logf(ctx, "remove %s", backup)
+		return os.RemoveAll(backup)
+	}
+	return nil
+}
+
+func MergeSkillContext(ctx context.Context, srcDir, destDir string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	destDir = filepath.Clean(destDir)
+	if err := CheckDistinct(srcDir, destDir); err != nil {
+		return err
+	}
+	info, err := os.Lstat(destDir)
+	if err != nil || !info.IsDir() {
+		return swapCopy(ctx, srcDir, destDir, progressFrom(ctx))
+	}
+	logf(ctx, "merge %s into %s", srcDir, destDir)
+	progress := progressFrom(ctx)
+	return filepath.WalkDir(srcDir, func(path string, d fs.DirEntry, walkErr error) error {
+		if walkErr != nil {
+			return walkErr
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(srcDir, path)
+		if err != nil {
+			return err
+		}
+		targetPath := filepath.Join(destDir, rel)
+		existing, existErr := os.Lstat(targetPath)
+		if d.IsDir() {
+			if existErr == nil && !existing.IsDir() {
+				return fmt.Errorf("cannot merge: %s exists and is not a directory", targetPath)
+			}
+			info, err := d.Info()
+			if err != nil {
+				return err
+			}
+			return os.MkdirAll(targetPath, info.Mode().Perm())
+		}
+		if existErr == nil {
+			if existing.IsDir() {
+				return fmt.Errorf("cannot merge: %s is a directory", targetPath)
+			}
+			if err := os.Remove(targetPath); err != nil {
+				return err
+			}
+		}
+		if d.Type()&os.ModeSymlink != 0 {
+			linkTarget, err := os.Readlink(path)
+			if err != nil {
+				return err
+			}
+			return os.Symlink(linkTarget, targetPath)
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		return copyFile(path, targetPath, info.Mode(), progress)
+	})
+}
+
+func MergeConflicts(srcDir, destDir string) ([]string, error) {
+	var conflicts []string
+	err := filepath.WalkDir(srcDir, func(path string, d fs.DirEntry, walkErr error) error {
+		if walkErr != nil {
+			return walkErr
+		}
+		if d.IsDir() || d.Type()&os.ModeSymlink != 0 {
+			return nil
+		}
+		rel, err := filepath.Rel(srcDir, path)
+		if err != nil {
+			return err
+		}
+		info, err := os.Lstat(filepath.Join(destDir, rel))
+		if err != nil {
+			return nil
+		}
+		if !info.Mode().IsRegular() || !sameFileContent(path, filepath.Join(destDir, rel)) {
+			conflicts = append(conflicts, filepath.ToSlash(rel))
+		}
+		return nil
+	})
+	return conflicts, err
+}
+
+func LinkSkillsRoot(skillsRoot, targetDir string) error {
+	if info, err := os.Lstat(targetDir); err == nil {
+		switch {
+		case info.Mode()&os.ModeSymlink != 0:
+			if err := os.Remove(targetDir); err != nil {
+				return fmt.Errorf("remove existing link: %w", err)
+			}
+		case info.IsDir():
+			entries, err := os.ReadDir(targetDir)
+			if err != nil {
+				return err
+			}
+			if len(entries) > 0 {
+				return fmt.Errorf("%s is not empty; remove its contents or install skills individually", targetDir)
+			}
+			if err := os.Remove(targetDir); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("%s exists and is not a directory", targetDir)
+		}
+	}
+	if err := os.MkdirAll(filepath.Dir(targetDir), 0o755); err != nil {
+		return fmt.Errorf("create parent dir: %w", err)
+	}
+	return os.Symlink(skillsRoot, targetDir)
+}
+
+func installHardlink(ctx context.Context, srcDir, destDir string) error {
+	if err := os.MkdirAll(filepath.Dir(destDir), 0o755); err != nil {
+		return fmt.Errorf("create parent dir: %w", err)
+	}
+	tmp, err := os.MkdirTemp(filepath.Dir(destDir), "."+filepath.Base(destDir)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	logf(ctx, "hard-link files from %s into %s", srcDir, tmp)
+	if err := linkTree(ctx, srcDir, tmp); err != nil {
+		_ = os.RemoveAll(tmp)
+		return err
+	}
+	return swapDir(ctx, tmp, destDir)
+}
+
+func linkTree(ctx context.Context, srcDir, destDir string) error {
+	return filepath.WalkDir(srcDir, func(path string, d fs.DirEntry, walkErr error) error {
+		if walkErr != nil {
+			return walkErr
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(srcDir, path)
+		if err != nil {
+			return err
+		}
+		targetPath := filepath.Join(destDir, rel)
+		switch {
+		case d.Type()&os.ModeSymlink != 0:
+			linkTarget, err := os.Readlink(path)
+			if err != nil {
+				return err
+			}
+			return os.Symlink(linkTarget, targetPath)
+		case d.IsDir():
+			info, err := d.Info()
+			if err != nil {
+				return err
+			}
+			if err := os.MkdirAll(targetPath, info.Mode().Perm()); err != nil {
+				return err
+			}
+			return os.Chmod(targetPath, info.Mode().Perm())
+		default:
+			return linkFile(path, targetPath)
+		}
+	})
+}
+
+func linkFile(src, dest string) error {
+	if err := os.Link(src, dest); err != nil {
+		if errors.Is(err, syscall.EXDEV) {
+			return fmt.Errorf("hardlink %s: source and target must be on the same filesystem", src)
+		}
+		return err
+	}
+	return nil
+}
+
+func renameDir(ctx context.Context, src, dest string) error {
+	err := os.Rename(src, dest)
+	if !errors.Is(err, syscall.EXDEV) {
+		return err
+	}
+	if err := copyDir(ctx, src, dest, nil); err != nil {
+		_ = os.RemoveAll(dest)
+		return err
+	}
+	return os.RemoveAll(src)
+}
+
+func copyDir(ctx context.Context, srcDir, destDir string, progress ProgressFunc) error {
 	return filepath.WalkDir(srcDir, func(path string, d fs.DirEntry, walkErr error) error {
 		if walkErr != nil {
 			return walkErr
 		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
 		rel, err := filepath.Rel(srcDir, path)
 		if err != nil {
 			return err
@@ -194,22 +964,31 @@ func copyDir(srcDir, destDir string) error {
 		if err := os.MkdirAll(filepath.Dir(targetPath), 0o755); err != nil {
 			return err
 		}
-		return copyFile(path, targetPath, info.Mode())
+		return copyFile(path, targetPath, info.Mode(), progress)
 	})
 }
 
-func copyFile(src, dest string, mode fs.FileMode) error {
+func copyFile(src, dest string, mode fs.FileMode, progress ProgressFunc) error {
 	in, err := os.Open(src)
 	if err != nil {
 		return err
 	}
 	defer in.Close()
 
+	var r io.Reader = in
+	if progress != nil {
+		info, err := in.Stat()
+		if err != nil {
+			return err
+		}
+		r = &progressReader{r: in, path: src, total: info.Size(), progress: progress}
+	}
+
 	out, err := os.Create(dest)
 	if err != nil {
 		return err
 	}
-	if _, err := io.Copy(out, in); err != nil {
+	if _, err := io.Copy(out, r); err != nil {
 		out.Close()
 		return err
 	}
@@ -219,42 +998,271 @@ func copyFile(src, dest string, mode fs.FileMode) error {
 	return os.Chmod(dest, mode)
 }
 
-func parseSkillFrontmatter(path string) (string, string, error) {
+type frontmatter struct {
+	name        string
+	description string
+	enabled     bool
+	priority    int
+	dependsOn   []string
+	aliases     []string
+	tags        []string
+	category    string
+	version     string
+	closed      bool
+}
+
+func parseSkillFrontmatter(path string) (frontmatter, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return frontmatter{}, err
+	}
+	defer file.Close()
+	return readFrontmatter(file)
+}
+
+type yamlFrontmatter struct {
+	Name        string     `yaml:"name"`
+	Description string     `yaml:"description"`
+	Enabled     string     `yaml:"enabled"`
+	Disabled    string     `yaml:"disabled"`
+	Priority    string     `yaml:"priority"`
+	DependsOn   stringList `yaml:"depends-on"`
+	Aliases     stringList `yaml:"aliases"`
+	Tags        stringList `yaml:"tags"`
+	Category    string     `yaml:"category"`
+	Version     string     `yaml:"version"`
+}
+
+type stringList []string
+
+func (l *stringList) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		*l = parseInlineList(node.Value)
+		return nil
+	}
+	var items []string
+	if err := node.Decode(&items); err != nil {
+		return err
+	}
+	*l = items
+	return nil
+}
+
+var KnownFrontmatterKeys = []string{"name", "description", "enabled", "disabled", "priority", "depends-on", "aliases", "tags", "category", "version"}
+
+func FrontmatterKeys(path string) ([]string, error) {
 	file, err := os.Open(path)
 	if err != nil {
-		return "", "", err
+		return nil, err
 	}
 	defer file.Close()
+	lines, _, err := frontmatterLines(file)
+	if err != nil {
+		return nil, err
+	}
+	var doc yaml.Node
+	if err := yaml.Unmarshal([]byte(strings.Join(lines, "\n")), &doc); err == nil && len(doc.Content) == 1 && doc.Content[0].Kind == yaml.MappingNode {
+		var keys []string
+		for i := 0; i < len(doc.Content[0].Content); i += 2 {
+			keys = append(keys, doc.Content[0].Content[i].Value)
+		}
+		return keys, nil
+	}
+	var keys []string
+	for _, line := range lines {
+		if line == "" || line[0] == ' ' || line[0] == '\t' || line[0] == '#' || line[0] == '-' {
+			continue
+		}
+		if key, _, ok := strings.Cut(line, ":"); ok {
+			keys = append(keys, strings.TrimSpace(key))
+		}
+	}
+	return keys, nil
+}
 
+func FrontmatterBlock(path string) ([]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	lines, closed, err := frontmatterLines(file)
+	if err != nil {
+		return nil, err
+	}
+	if !closed {
+		return nil, nil
+	}
+	return lines, nil
+}
+
+type FrontmatterInfo struct {
+	Fenced      bool
+	Name        string
+	Description string
+	Version     string
+}
+
+func InspectFrontmatter(path string) (FrontmatterInfo, error) {
+	meta, err := parseSkillFrontmatter(path)
+	if err != nil {
+		return FrontmatterInfo{}, err
+	}
+	return FrontmatterInfo{Fenced: meta.closed, Name: meta.name, Description: meta.description, Version: meta.version}, nil
+}
+
+func ReadSkillBody(path string, maxLines int) ([]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
 	scanner := bufio.NewScanner(file)
-	lineNo := 0
+	var lines []string
 	inFrontmatter := false
-	var name, desc string
-	for scanner.Scan() {
+	for lineNo := 1; scanner.Scan(); lineNo++ {
 		line := scanner.Text()
-		lineNo++
 		if lineNo == 1 && strings.TrimSpace(line) == "---" {
 			inFrontmatter = true
 			continue
 		}
-		if inFrontmatter && strings.TrimSpace(line) == "---" {
+		if inFrontmatter {
+			if strings.TrimSpace(line) == "---" {
+				inFrontmatter = false
+			}
+			continue
+		}
+		if len(lines) == 0 && strings.TrimSpace(line) == "" {
+			continue
+		}
+		lines = append(lines, line)
+		if len(lines) == maxLines {
 			break
 		}
-		if !inFrontmatter {
+	}
+	return lines, scanner.Err()
+}
+
+func frontmatterLines(r io.Reader) ([]string, bool, error) {
+	scanner := bufio.NewScanner(r)
+	var lines []string
+	closed := false
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := scanner.Text()
+		if lineNo == 1 {
+			if strings.TrimSpace(line) != "---" {
+				break
+			}
+			continue
+		}
+		if strings.TrimSpace(line) == "---" {
+			closed = true
 			break
 		}
+		lines = append(lines, line)
+	}
+	return lines, closed, scanner.Err()
+}
+
+func readFrontmatter(r io.Reader) (frontmatter, error) {
+	meta := frontmatter{enabled: true}
+	lines, closed, err := frontmatterLines(r)
+	if err != nil {
+		return frontmatter{}, err
+	}
+	meta.closed = closed
+
+	var raw yamlFrontmatter
+	if err := yaml.Unmarshal([]byte(strings.Join(lines, "\n")), &raw); err != nil {
+		return scanFrontmatterLines(lines, meta), nil
+	}
+	meta.name = strings.TrimSpace(raw.Name)
+	meta.description = strings.Join(strings.Fields(raw.Description), " ")
+	if enabled, ok := parseBool(raw.Enabled); ok {
+		meta.enabled = enabled
+	}
+	if disabled, ok := parseBool(raw.Disabled); ok && disabled {
+		meta.enabled = false
+	}
+	if priority, err := strconv.Atoi(strings.TrimSpace(raw.Priority)); err == nil {
+		meta.priority = priority
+	}
+	meta.dependsOn = raw.DependsOn
+	meta.aliases = raw.Aliases
+	meta.tags = raw.Tags
+	meta.category = strings.TrimSpace(raw.Category)
+	meta.version = strings.TrimSpace(raw.Version)
+	return meta, nil
+}
+
+func scanFrontmatterLines(lines []string, meta frontmatter) frontmatter {
+	for _, line := range lines {
 		trimmed := strings.TrimSpace(line)
 		if strings.HasPrefix(trimmed, "name:") {
-			name = strings.TrimSpace(strings.TrimPrefix(trimmed, "name:"))
+			meta.name = strings.TrimSpace(strings.TrimPrefix(trimmed, "name:"))
 		}
 		if strings.HasPrefix(trimmed, "description:") {
-			desc = strings.TrimSpace(strings.TrimPrefix(trimmed, "description:"))
+			meta.description = strings.TrimSpace(strings.TrimPrefix(trimmed, "description:"))
+		}
+		if strings.HasPrefix(trimmed, "enabled:") {
+			value := strings.TrimSpace(strings.TrimPrefix(trimmed, "enabled:"))
+			if enabled, ok := parseBool(value); ok {
+				meta.enabled = enabled
+			}
+		}
+		if strings.HasPrefix(trimmed, "disabled:") {
+			value := strings.TrimSpace(strings.TrimPrefix(trimmed, "disabled:"))
+			if disabled, ok := parseBool(value); ok && disabled {
+				meta.enabled = false
+			}
+		}
+		if strings.HasPrefix(trimmed, "priority:") {
+			value := strings.TrimSpace(strings.TrimPrefix(trimmed, "priority:"))
+			if priority, err := strconv.Atoi(value); err == nil {
+				meta.priority = priority
+			}
+		}
+		if strings.HasPrefix(trimmed, "depends-on:") {
+			meta.dependsOn = parseInlineList(strings.TrimPrefix(trimmed, "depends-on:"))
+		}
+		if strings.HasPrefix(trimmed, "aliases:") {
+			meta.aliases = parseInlineList(strings.TrimPrefix(trimmed, "aliases:"))
+		}
+		if strings.HasPrefix(trimmed, "tags:") {
+			meta.tags = parseInlineList(strings.TrimPrefix(trimmed, "tags:"))
+		}
+		if strings.HasPrefix(trimmed, "category:") {
+			meta.category = strings.Trim(strings.TrimSpace(strings.TrimPrefix(trimmed, "category:")), `"'`)
+		}
+		if strings.HasPrefix(trimmed, "version:") {
+			meta.version = strings.Trim(strings.TrimSpace(strings.TrimPrefix(trimmed, "version:")), `"'`)
 		}
 	}
-	if err := scanner.Err(); err != nil {
-		return "", "", err
+	return meta
+}
+
+func parseInlineList(value string) []string {
+	value = strings.TrimSpace(value)
+	value = strings.TrimSuffix(strings.TrimPrefix(value, "["), "]")
+	var items []string
+	for _, part := range strings.Split(value, ",") {
+		part = strings.Trim(strings.TrimSpace(part), `"'`)
+		if part != "" {
+			items = append(items, part)
+		}
+	}
+	return items
+}
+
+func parseBool(value string) (bool, bool) {
+	switch strings.ToLower(strings.Trim(value, `"'`)) {
+	case "true", "yes", "on":
+		return true, true
+	case "false", "no", "off":
+		return false, true
+	default:
+		return false, false
 	}
-	return name, desc, nil
 }
 
 func existsDir(path string) bool {
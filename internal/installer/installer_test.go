@@ -0,0 +1,452 @@
+package installer
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func assertNoTempSiblings(t *testing.T, dir string) {
+	t.Helper()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, entry := range entries {
+		if strings.Contains(entry.Name(), ".tmp-") {
+			t.Errorf("left behind %s", entry.Name())
+		}
+	}
+}
+
+func TestInstallHardlinkLinksFiles(t *testing.T) {
+	root := t.TempDir()
+	src := filepath.Join(root, "src", "skill")
+	dest := filepath.Join(root, "target", "skill")
+	writeFile(t, filepath.Join(src, "SKILL.md"), "skill")
+	writeFile(t, filepath.Join(src, "refs", "a.md"), "a")
+	writeFile(t, filepath.Join(dest, "old.md"), "old")
+
+	if err := InstallSkillContext(context.Background(), src, dest, ModeHardlink); err != nil {
+		t.Fatal(err)
+	}
+	for _, rel := range []string{"SKILL.md", filepath.Join("refs", "a.md")} {
+		srcInfo, err := os.Stat(filepath.Join(src, rel))
+		if err != nil {
+			t.Fatal(err)
+		}
+		destInfo, err := os.Stat(filepath.Join(dest, rel))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !os.SameFile(srcInfo, destInfo) {
+			t.Errorf("%s is not a hard link to the source", rel)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(dest, "old.md")); err == nil {
+		t.Error("old install contents survived the replace")
+	}
+	assertNoTempSiblings(t, filepath.Dir(dest))
+}
+
+func TestInstallHardlinkFailureKeepsExistingInstall(t *testing.T) {
+	root := t.TempDir()
+	src := filepath.Join(root, "src", "skill")
+	dest := filepath.Join(root, "target", "skill")
+	writeFile(t, filepath.Join(src, "SKILL.md"), "new")
+	writeFile(t, filepath.Join(dest, "SKILL.md"), "existing")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := installHardlink(ctx, src, dest); err == nil {
+		t.Fatal("installHardlink succeeded with a canceled context")
+	}
+	if got := readFile(t, filepath.Join(dest, "SKILL.md")); got != "existing" {
+		t.Fatalf("existing install = %q, want it untouched", got)
+	}
+	assertNoTempSiblings(t, filepath.Dir(dest))
+}
+
+func TestInstallHardlinkReplacesSymlinkInstall(t *testing.T) {
+	root := t.TempDir()
+	src := filepath.Join(root, "src", "skill")
+	dest := filepath.Join(root, "target", "skill")
+	writeFile(t, filepath.Join(src, "SKILL.md"), "skill")
+	if err := InstallSkillContext(context.Background(), src, dest, ModeSymlink); err != nil {
+		t.Fatal(err)
+	}
+	if err := InstallSkillContext(context.Background(), src, dest, ModeHardlink); err != nil {
+		t.Fatal(err)
+	}
+	info, err := os.Lstat(dest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode()&os.ModeSymlink != 0 || !info.IsDir() {
+		t.Fatalf("dest mode = %v, want a real directory", info.Mode())
+	}
+}
+
+func TestMergeSkillOverlaysExistingDir(t *testing.T) {
+	root := t.TempDir()
+	src := filepath.Join(root, "src", "skill")
+	dest := filepath.Join(root, "target", "skill")
+	writeFile(t, filepath.Join(src, "SKILL.md"), "new skill")
+	writeFile(t, filepath.Join(src, "refs", "a.md"), "a")
+	writeFile(t, filepath.Join(src, "same.md"), "same")
+	writeFile(t, filepath.Join(dest, "SKILL.md"), "old skill")
+	writeFile(t, filepath.Join(dest, "same.md"), "same")
+	writeFile(t, filepath.Join(dest, "notes.md"), "mine")
+	writeFile(t, filepath.Join(dest, "refs", "local.md"), "local")
+
+	conflicts, err := MergeConflicts(src, dest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(conflicts) != 1 || conflicts[0] != "SKILL.md" {
+		t.Fatalf("MergeConflicts = %v, want [SKILL.md]", conflicts)
+	}
+	if err := MergeSkillContext(context.Background(), src, dest); err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{
+		"SKILL.md":                        "new skill",
+		filepath.Join("refs", "a.md"):     "a",
+		"same.md":                         "same",
+		"notes.md":                        "mine",
+		filepath.Join("refs", "local.md"): "local",
+	}
+	for rel, content := range want {
+		if got := readFile(t, filepath.Join(dest, rel)); got != content {
+			t.Errorf("%s = %q, want %q", rel, got, content)
+		}
+	}
+}
+
+func TestMergeSkillRejectsFileOverDir(t *testing.T) {
+	root := t.TempDir()
+	src := filepath.Join(root, "src", "skill")
+	dest := filepath.Join(root, "target", "skill")
+	writeFile(t, filepath.Join(src, "SKILL.md"), "skill")
+	writeFile(t, filepath.Join(src, "refs"), "file")
+	writeFile(t, filepath.Join(dest, "refs", "local.md"), "local")
+
+	if err := MergeSkillContext(context.Background(), src, dest); err == nil {
+		t.Fatal("merging a file over a directory succeeded")
+	}
+	if got := readFile(t, filepath.Join(dest, "refs", "local.md")); got != "local" {
+		t.Fatalf("existing file = %q, want it untouched", got)
+	}
+}
+
+func TestMergeSkillWithoutExistingDirCopies(t *testing.T) {
+	root := t.TempDir()
+	src := filepath.Join(root, "src", "skill")
+	dest := filepath.Join(root, "target", "skill")
+	writeFile(t, filepath.Join(src, "SKILL.md"), "skill")
+
+	if err := MergeSkillContext(context.Background(), src, dest); err != nil {
+		t.Fatal(err)
+	}
+	if got := readFile(t, filepath.Join(dest, "SKILL.md")); got != "skill" {
+		t.Fatalf("SKILL.md = %q", got)
+	}
+	assertNoTempSiblings(t, filepath.Dir(dest))
+}
+
+func TestDiscoverSkillsReadsDescriptionFile(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, "empty", "SKILL.md"), "---\n---\nbody\n")
+	writeFile(t, filepath.Join(root, "empty", DefaultDescriptionFile), "\n  Reviews pull requests.  \nMore detail.\n")
+	writeFile(t, filepath.Join(root, "inline", "SKILL.md"), "---\ndescription: From frontmatter\n---\n")
+	writeFile(t, filepath.Join(root, "inline", DefaultDescriptionFile), "From file\n")
+	writeFile(t, filepath.Join(root, "long", "SKILL.md"), "---\nname: long\ndescription: \"\"\n---\n")
+	writeFile(t, filepath.Join(root, "long", DefaultDescriptionFile), strings.Repeat("é", maxDescriptionLen+10))
+	writeFile(t, filepath.Join(root, "custom", "SKILL.md"), "---\n---\n")
+	writeFile(t, filepath.Join(root, "custom", "ABOUT.md"), "Custom file\n")
+
+	descriptions := func(descriptionFile string) map[string]string {
+		skills, err := DiscoverSkillsWithDescriptionFile(root, descriptionFile)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got := map[string]string{}
+		for _, skill := range skills {
+			got[skill.Name] = skill.Description
+		}
+		return got
+	}
+
+	got := descriptions(DefaultDescriptionFile)
+	if got["empty"] != "Reviews pull requests." {
+		t.Errorf("empty frontmatter description = %q", got["empty"])
+	}
+	if got["inline"] != "From frontmatter" {
+		t.Errorf("frontmatter description = %q, want it to win over the file", got["inline"])
+	}
+	if runes := []rune(got["long"]); len(runes) != maxDescriptionLen || !strings.HasSuffix(got["long"], "...") {
+		t.Errorf("long description = %q, want %d runes ending in ...", got["long"], maxDescriptionLen)
+	}
+	if got["custom"] != "" {
+		t.Errorf("custom description = %q, want none with the default file name", got["custom"])
+	}
+	if got := descriptions("ABOUT.md"); got["custom"] != "Custom file" || got["empty"] != "" {
+		t.Errorf("with ABOUT.md: %v", got)
+	}
+}
+
+func TestDiscoverTargetsProjectIsHome(t *testing.T) {
+	home := t.TempDir()
+	for _, dir := range []string{".claude/skills", ".cursor/skills"} {
+		if err := os.MkdirAll(filepath.Join(home, filepath.FromSlash(dir)), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	link := filepath.Join(t.TempDir(), "project")
+	if err := os.Symlink(home, link); err != nil {
+		t.Fatal(err)
+	}
+
+	for name, project := range map[string]string{"same path": home, "trailing slash": home + "/", "symlink": link} {
+		targets := DedupeTargetsByRealPath(DiscoverTargets(home, project))
+		seen := map[string]TargetType{}
+		for _, target := range targets {
+			key := realPath(target.Path)
+			if prev, ok := seen[key]; ok {
+				t.Errorf("%s: %s and %s both install to %s", name, prev, target.Type, key)
+			}
+			seen[key] = target.Type
+		}
+		claude := 0
+		for _, target := range targets {
+			if filepath.Base(filepath.Dir(target.Path)) == ".claude" {
+				claude++
+			}
+		}
+		if claude != 1 {
+			t.Errorf("%s: %d Claude targets, want 1: %+v", name, claude, targets)
+		}
+	}
+}
+
+func TestInstallSkillSameLocationKeepsSource(t *testing.T) {
+	root := t.TempDir()
+	src := filepath.Join(root, "skills", "review")
+	writeFile(t, filepath.Join(src, "SKILL.md"), "skill")
+	link := filepath.Join(root, "target")
+	if err := os.Symlink(filepath.Join(root, "skills"), link); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, dest := range []string{src, src + "/", filepath.Join(link, "review")} {
+		for _, mode := range []Mode{ModeSymlink, ModeCopy, ModeHardlink} {
+			err := InstallSkill(src, dest, mode)
+			if !errors.Is(err, ErrSameLocation) {
+				t.Errorf("InstallSkill(%s, %s) error = %v, want ErrSameLocation", dest, mode, err)
+			}
+			if err := MergeSkillContext(context.Background(), src, dest); !errors.Is(err, ErrSameLocation) {
+				t.Errorf("MergeSkillContext(%s) error = %v, want ErrSameLocation", dest, err)
+			}
+			if got := readFile(t, filepath.Join(src, "SKILL.md")); got != "skill" {
+				t.Fatalf("source SKILL.md = %q after installing onto itself", got)
+			}
+		}
+	}
+	file := filepath.Join(src, "SKILL.md")
+	if err := InstallSkillFile(file, file, ModeCopy); !errors.Is(err, ErrSameLocation) {
+		t.Errorf("InstallSkillFile error = %v, want ErrSameLocation", err)
+	}
+	if got := readFile(t, file); got != "skill" {
+		t.Fatalf("source file = %q after installing onto itself", got)
+	}
+}
+
+func TestDiscoverSkillsReadsEnabled(t *testing.T) {
+	root := t.TempDir()
+	for name, frontmatter := range map[string]string{
+		"default":  "name: default",
+		"on":       "name: on\nenabled: true",
+		"off":      "name: off\nenabled: false",
+		"disabled": "name: disabled\ndisabled: true",
+		"quoted":   "name: quoted\nenabled: \"no\"",
+	} {
+		writeFile(t, filepath.Join(root, name, "SKILL.md"), "---\n"+frontmatter+"\n---\n")
+	}
+	skills, err := DiscoverSkills(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]bool{"default": true, "on": true, "off": false, "disabled": false, "quoted": false}
+	for _, skill := range skills {
+		if skill.Enabled != want[skill.Name] {
+			t.Errorf("%s: Enabled = %v, want %v", skill.Name, skill.Enabled, want[skill.Name])
+		}
+	}
+	if len(skills) != len(want) {
+		t.Errorf("discovered %d skills, want %d (disabled skills are still discovered)", len(skills), len(want))
+	}
+}
+
+func TestDiscoverFileSkills(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, "review.md"), "---\nname: code-review\ndescription: Reviews code\ntags: [git]\n---\nbody\n")
+	writeFile(t, filepath.Join(root, "unnamed.md"), "---\ndescription: No name\n---\n")
+	writeFile(t, filepath.Join(root, "notes.md"), "# plain markdown\n")
+	writeFile(t, filepath.Join(root, "open.md"), "---\nname: open\n")
+	writeFile(t, filepath.Join(root, "skip.md"), "---\nname: skip\n---\n")
+	writeFile(t, filepath.Join(root, "readme.txt"), "---\nname: txt\n---\n")
+	writeFile(t, filepath.Join(root, "dir", "SKILL.md"), "---\nname: dir\n---\n")
+	writeFile(t, filepath.Join(root, IgnoreFile), "skip.md\n")
+
+	skills, err := DiscoverFileSkills(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := map[string]Skill{}
+	for _, skill := range skills {
+		got[skill.Name] = skill
+	}
+	if len(got) != 2 {
+		t.Fatalf("discovered %+v, want code-review and unnamed", skills)
+	}
+	review := got["code-review"]
+	if review.Path != filepath.Join(root, "review.md") || review.Description != "Reviews code" || len(review.Tags) != 1 || !review.Enabled {
+		t.Errorf("code-review = %+v", review)
+	}
+	if unnamed, ok := got["unnamed"]; !ok || unnamed.Path != filepath.Join(root, "unnamed.md") {
+		t.Errorf("unnamed = %+v, %v", unnamed, ok)
+	}
+
+	empty := t.TempDir()
+	writeFile(t, filepath.Join(empty, "dir", "SKILL.md"), "---\nname: dir\n---\n")
+	if _, err := DiscoverFileSkills(empty); err == nil {
+		t.Error("a directory-only skills root yielded single-file skills")
+	}
+}
+
+func TestInstallSkillFileLayouts(t *testing.T) {
+	root := t.TempDir()
+	src := filepath.Join(root, "skills", "review.md")
+	writeFile(t, src, "---\nname: review\n---\n")
+	for _, mode := range []Mode{ModeSymlink, ModeCopy, ModeHardlink} {
+		dest := filepath.Join(root, string(mode), "review.md")
+		if got := CheckInstalled(src, dest, mode, LayoutFlat); got != StateMissing {
+			t.Errorf("%s before install: %s", mode, got)
+		}
+		if err := InstallSkillFile(src, dest, mode); err != nil {
+			t.Fatal(err)
+		}
+		info, err := os.Lstat(dest)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if isLink := info.Mode()&os.ModeSymlink != 0; isLink != (mode == ModeSymlink) || !isLink && !info.Mode().IsRegular() {
+			t.Errorf("%s installed a %v, want a file", mode, info.Mode())
+		}
+		if err := VerifyInstall(dest, LayoutFlat); err != nil {
+			t.Errorf("%s: %v", mode, err)
+		}
+		if got := CheckInstalled(src, dest, mode, LayoutFlat); got != StateCurrent {
+			t.Errorf("%s after install: %s", mode, got)
+		}
+	}
+	dest := filepath.Join(root, string(ModeCopy), "review.md")
+	if err := os.WriteFile(dest, []byte("edited"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if got := CheckInstalled(src, dest, ModeCopy, LayoutFlat); got != StateDrifted {
+		t.Errorf("edited copy: %s, want drifted", got)
+	}
+}
+
+func TestSwapCopyFailureKeepsOriginal(t *testing.T) {
+	root := t.TempDir()
+	src := filepath.Join(root, "src", "skill")
+	dest := filepath.Join(root, "target", "skill")
+	writeFile(t, filepath.Join(src, "SKILL.md"), "new")
+	writeFile(t, filepath.Join(src, "refs", "big.md"), strings.Repeat("x", 4096))
+	writeFile(t, filepath.Join(dest, "SKILL.md"), "original")
+	writeFile(t, filepath.Join(dest, "local.md"), "mine")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	copied := 0
+	ctx = WithProgress(ctx, func(string, int64, int64) {
+		if copied++; copied == 1 {
+			cancel()
+		}
+	})
+	if err := swapCopy(ctx, src, dest, progressFrom(ctx)); !errors.Is(err, context.Canceled) {
+		t.Fatalf("swapCopy error = %v, want context.Canceled", err)
+	}
+	if got := readFile(t, filepath.Join(dest, "SKILL.md")); got != "original" {
+		t.Errorf("SKILL.md = %q, want the original", got)
+	}
+	if got := readFile(t, filepath.Join(dest, "local.md")); got != "mine" {
+		t.Errorf("local.md = %q, want it kept", got)
+	}
+	assertNoTempSiblings(t, filepath.Dir(dest))
+}
+
+func TestSwapDirRenameFailureRestoresOriginal(t *testing.T) {
+	root := t.TempDir()
+	dest := filepath.Join(root, "target", "skill")
+	writeFile(t, filepath.Join(dest, "SKILL.md"), "original")
+	missing := filepath.Join(root, "target", ".skill.tmp-missing")
+
+	if err := swapDir(context.Background(), missing, dest); err == nil {
+		t.Fatal("swapDir with a missing staged dir succeeded")
+	}
+	if got := readFile(t, filepath.Join(dest, "SKILL.md")); got != "original" {
+		t.Errorf("SKILL.md = %q, want the original restored", got)
+	}
+	assertNoTempSiblings(t, filepath.Dir(dest))
+}
+
+func TestDiscoverSkillsUnicodeNames(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, "Code Review 🔍", "SKILL.md"), "---\ndescription: Reviews code\n---\n")
+	writeFile(t, filepath.Join(root, "équipe", "résumé", "SKILL.md"), "---\nname: résumé\n---\n")
+
+	skills, err := DiscoverSkills(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := map[string]string{}
+	for _, skill := range skills {
+		got[skill.Name] = InstallName(skill)
+	}
+	want := map[string]string{"Code Review 🔍": "Code Review 🔍", "résumé": "équipe-résumé"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("install names = %v, want %v", got, want)
+	}
+	dest := filepath.Join(root, "target", "Code Review 🔍")
+	if err := InstallSkill(filepath.Join(root, "Code Review 🔍"), dest, ModeCopy); err != nil {
+		t.Fatal(err)
+	}
+	if err := VerifyInstall(dest, LayoutDir); err != nil {
+		t.Fatal(err)
+	}
+}
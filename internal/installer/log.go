@@ -0,0 +1,17 @@
+package installer
+
+import "context"
+
+type LogFunc func(format string, args ...any)
+
+type logKey struct{}
+
+func WithLogger(ctx context.Context, log LogFunc) context.Context {
+	return context.WithValue(ctx, logKey{}, log)
+}
+
+func logf(ctx context.Context, format string, args ...any) {
+	if log, ok := ctx.Value(logKey{}).(LogFunc); ok && log != nil {
+		log(format, args...)
+	}
+}
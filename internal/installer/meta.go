@@ -0,0 +1,202 @@
+package installer
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const metaSuffix = ".askill-meta"
+
+const SourceStampFile = ".askill-source"
+
+type Layout string
+
+const (
+	LayoutDir  Layout = "dir"
+	LayoutFlat Layout = "flat"
+	LayoutRoot Layout = "root"
+)
+
+const AllSkills = "*"
+
+type Meta struct {
+	Skill        string `json:"skill"`
+	Source       string `json:"source"`
+	Mode         Mode   `json:"mode"`
+	Layout       Layout `json:"layout"`
+	OriginalName string `json:"original_name,omitempty"`
+	Version      string `json:"version,omitempty"`
+	Merged       bool   `json:"merged,omitempty"`
+}
+
+func MetaPath(dest string) string {
+	return dest + metaSuffix
+}
+
+func WriteMeta(dest string, meta Meta) error {
+	data, err := json.MarshalIndent(meta, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(MetaPath(dest), append(data, '\n'), 0o644)
+}
+
+func ReadMeta(dest string) (Meta, error) {
+	data, err := os.ReadFile(MetaPath(dest))
+	if err != nil {
+		return Meta{}, err
+	}
+	var meta Meta
+	if err := json.Unmarshal(data, &meta); err != nil {
+		return Meta{}, err
+	}
+	return meta, nil
+}
+
+func IsMetaFile(name string) bool {
+	return strings.HasSuffix(name, metaSuffix)
+}
+
+func HasMeta(dest string) bool {
+	info, err := os.Stat(MetaPath(dest))
+	return err == nil && !info.IsDir()
+}
+
+func MatchInstalled(dest string, skills []Skill) (Meta, bool) {
+	info, err := os.Lstat(dest)
+	if err != nil {
+		return Meta{}, false
+	}
+	base := filepath.Base(dest)
+	if info.Mode()&os.ModeSymlink != 0 {
+		resolved, err := filepath.EvalSymlinks(dest)
+		if err != nil {
+			return Meta{}, false
+		}
+		for _, skill := range skills {
+			skillPath, err := filepath.EvalSymlinks(skill.Path)
+			if err != nil {
+				continue
+			}
+			if resolved == skillPath {
+				return Meta{Skill: skill.Name, Source: skill.Path, Mode: ModeSymlink, Layout: LayoutDir}, true
+			}
+			if resolved == filepath.Join(skillPath, "SKILL.md") {
+				return Meta{Skill: skill.Name, Source: skill.Path, Mode: ModeSymlink, Layout: LayoutFlat}, true
+			}
+		}
+		return Meta{}, false
+	}
+
+	layout := LayoutDir
+	name := base
+	installedSkillFile := filepath.Join(dest, "SKILL.md")
+	switch {
+	case info.IsDir():
+	case info.Mode().IsRegular() && strings.HasSuffix(base, ".md"):
+		layout = LayoutFlat
+		name = strings.TrimSuffix(base, ".md")
+		installedSkillFile = dest
+	default:
+		return Meta{}, false
+	}
+	for _, skill := range skills {
+		if InstallName(skill) == name {
+			return Meta{Skill: skill.Name, Source: skill.Path, Mode: ModeCopy, Layout: layout}, true
+		}
+	}
+	for _, skill := range skills {
+		if sameFileContent(installedSkillFile, filepath.Join(skill.Path, "SKILL.md")) {
+			return Meta{Skill: skill.Name, Source: skill.Path, Mode: ModeCopy, Layout: layout}, true
+		}
+	}
+	return Meta{}, false
+}
+
+func sameFileContent(a, b string) bool {
+	aData, err := os.ReadFile(a)
+	if err != nil {
+		return false
+	}
+	bData, err := os.ReadFile(b)
+	if err != nil {
+		return false
+	}
+	return bytes.Equal(aData, bData)
+}
+
+func IsManagedSkill(dest string) bool {
+	return HasMeta(dest)
+}
+
+func RemoveSkill(dest string) error {
+	if err := os.RemoveAll(dest); err != nil {
+		return err
+	}
+	if err := os.Remove(MetaPath(dest)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+type EntryStatus string
+
+const (
+	EntryValid    EntryStatus = "valid"
+	EntryDangling EntryStatus = "dangling"
+	EntryCopy     EntryStatus = "copy"
+)
+
+type InstalledEntry struct {
+	Name       string
+	Path       string
+	Status     EntryStatus
+	LinkTarget string
+}
+
+func InstalledEntries(target Target) ([]InstalledEntry, error) {
+	info, err := os.Lstat(target.Path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if info.Mode()&os.ModeSymlink != 0 {
+		entry := symlinkEntry(target.Path)
+		if entry.Status == EntryDangling {
+			return []InstalledEntry{entry}, nil
+		}
+	}
+	dirEntries, err := os.ReadDir(target.Path)
+	if err != nil {
+		return nil, err
+	}
+	var entries []InstalledEntry
+	for _, dirEntry := range dirEntries {
+		name := dirEntry.Name()
+		if strings.HasPrefix(name, ".") || IsMetaFile(name) {
+			continue
+		}
+		path := filepath.Join(target.Path, name)
+		if dirEntry.Type()&os.ModeSymlink != 0 {
+			entries = append(entries, symlinkEntry(path))
+			continue
+		}
+		entries = append(entries, InstalledEntry{Name: name, Path: path, Status: EntryCopy})
+	}
+	return entries, nil
+}
+
+func symlinkEntry(path string) InstalledEntry {
+	entry := InstalledEntry{Name: filepath.Base(path), Path: path, Status: EntryValid}
+	entry.LinkTarget, _ = os.Readlink(path)
+	if _, err := os.Stat(path); err != nil {
+		entry.Status = EntryDangling
+	}
+	return entry
+}
@@ -0,0 +1,36 @@
+package installer
+
+import (
+	"context"
+	"io"
+)
+
+type ProgressFunc func(path string, copied, total int64)
+
+type progressKey struct{}
+
+func WithProgress(ctx context.Context, progress ProgressFunc) context.Context {
+	return context.WithValue(ctx, progressKey{}, progress)
+}
+
+func progressFrom(ctx context.Context) ProgressFunc {
+	progress, _ := ctx.Value(progressKey{}).(ProgressFunc)
+	return progress
+}
+
+type progressReader struct {
+	r        io.Reader
+	path     string
+	copied   int64
+	total    int64
+	progress ProgressFunc
+}
+
+func (p *progressReader) Read(buf []byte) (int, error) {
+	n, err := p.r.Read(buf)
+	if n > 0 {
+		p.copied += int64(n)
+		p.progress(p.path, p.copied, p.total)
+	}
+	return n, err
+}
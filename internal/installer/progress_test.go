@@ -0,0 +1,49 @@
+package installer
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCopyProgressReportsFullByteCount(t *testing.T) {
+	root := t.TempDir()
+	src := filepath.Join(root, "src", "skill")
+	sizes := map[string]int{
+		"SKILL.md":                     12,
+		filepath.Join("models", "big"): 3*1024*1024 + 17,
+	}
+	for rel, size := range sizes {
+		writeFile(t, filepath.Join(src, rel), strings.Repeat("x", size))
+	}
+
+	for _, install := range []struct {
+		name string
+		run  func(ctx context.Context, dest string) error
+	}{
+		{"copy", func(ctx context.Context, dest string) error { return InstallSkillContext(ctx, src, dest, ModeCopy) }},
+		{"merge", func(ctx context.Context, dest string) error { return MergeSkillContext(ctx, src, dest) }},
+	} {
+		dest := filepath.Join(root, install.name, "skill")
+		writeFile(t, filepath.Join(dest, "extra.md"), "keep")
+		last := map[string]int64{}
+		totals := map[string]int64{}
+		ctx := WithProgress(context.Background(), func(path string, copied, total int64) {
+			if copied < last[path] {
+				t.Errorf("%s: %s went backwards from %d to %d", install.name, path, last[path], copied)
+			}
+			last[path] = copied
+			totals[path] = total
+		})
+		if err := install.run(ctx, dest); err != nil {
+			t.Fatal(err)
+		}
+		for rel, size := range sizes {
+			path := filepath.Join(src, rel)
+			if last[path] != int64(size) || totals[path] != int64(size) {
+				t.Errorf("%s: %s reported %d of %d bytes, want %d", install.name, rel, last[path], totals[path], size)
+			}
+		}
+	}
+}
@@ -0,0 +1,129 @@
+package installer
+
+import (
+	"archive/zip"
+	"errors"
+	"fmt"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+func DiscoverZipSkills(zipPath string) ([]Skill, error) {
+	reader, err := zip.OpenReader(zipPath)
+	if err != nil {
+		return nil, fmt.Errorf("open %s: %w", zipPath, err)
+	}
+	defer reader.Close()
+
+	root := zipSkillsRoot(reader.File)
+	ignore, err := zipIgnoreRules(reader.File, root)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", zipPath, err)
+	}
+	files := make(map[string]*zip.File)
+	var dirs []string
+	for _, file := range reader.File {
+		name := strings.TrimPrefix(file.Name, "./")
+		if path.Base(name) != "SKILL.md" || file.FileInfo().IsDir() {
+			continue
+		}
+		dir := path.Dir(name)
+		if !strings.HasPrefix(dir+"/", root+"/") {
+			continue
+		}
+		if dir != root && ignore.ignoredPath(strings.TrimPrefix(dir, root+"/")) {
+			continue
+		}
+		files[dir] = file
+		dirs = append(dirs, dir)
+	}
+	sort.Strings(dirs)
+
+	var skills []Skill
+	var accepted []string
+	for _, dir := range dirs {
+		if nestedUnder(dir, accepted) {
+			continue
+		}
+		accepted = append(accepted, dir)
+		meta, err := readZipFrontmatter(files[dir])
+		if err != nil {
+			return nil, fmt.Errorf("parse %s in %s: %w", files[dir].Name, zipPath, err)
+		}
+		name := meta.name
+		if name == "" {
+			name = path.Base(dir)
+		}
+		namespace := ""
+		if parent := path.Dir(dir); parent != root && strings.HasPrefix(parent, root+"/") {
+			namespace = strings.TrimPrefix(parent, root+"/")
+		}
+		skills = append(skills, Skill{
+			Name:        name,
+			Description: meta.description,
+			Path:        filepath.Join(zipPath, filepath.FromSlash(dir)),
+			Enabled:     meta.enabled,
+			Priority:    meta.priority,
+			DependsOn:   meta.dependsOn,
+			Aliases:     meta.aliases,
+			Tags:        meta.tags,
+			Category:    meta.category,
+			Version:     meta.version,
+			Namespace:   namespace,
+		})
+	}
+	if len(skills) == 0 {
+		return nil, errors.New("no skills found")
+	}
+	return skills, nil
+}
+
+func zipSkillsRoot(files []*zip.File) string {
+	top := ""
+	for _, file := range files {
+		first, _, _ := strings.Cut(strings.TrimPrefix(file.Name, "./"), "/")
+		if top != "" && first != top {
+			return "skills"
+		}
+		top = first
+	}
+	if top == "" || top == "skills" {
+		return "skills"
+	}
+	return top + "/skills"
+}
+
+func zipIgnoreRules(files []*zip.File, root string) (ignoreRules, error) {
+	for _, file := range files {
+		if strings.TrimPrefix(file.Name, "./") != root+"/"+IgnoreFile {
+			continue
+		}
+		rc, err := file.Open()
+		if err != nil {
+			return nil, err
+		}
+		defer rc.Close()
+		return parseIgnoreRules(rc)
+	}
+	return nil, nil
+}
+
+func nestedUnder(dir string, parents []string) bool {
+	for _, parent := range parents {
+		if strings.HasPrefix(dir+"/", parent+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+func readZipFrontmatter(file *zip.File) (frontmatter, error) {
+	rc, err := file.Open()
+	if err != nil {
+		return frontmatter{}, err
+	}
+	defer rc.Close()
+	return readFrontmatter(rc)
+}